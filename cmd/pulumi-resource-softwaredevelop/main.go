@@ -0,0 +1,17 @@
+// Command pulumi-resource-softwaredevelop is the component provider plugin
+// that makes the components usable from Pulumi YAML, TypeScript and Python.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/softwaredevelop/pulumi-go-components/provider"
+)
+
+func main() {
+	if err := provider.Main(); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
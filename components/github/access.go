@@ -0,0 +1,113 @@
+package github
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// Permission is the level of access granted to a team or collaborator.
+type Permission string
+
+const (
+	// PermissionPull grants read access.
+	PermissionPull Permission = "pull"
+	// PermissionTriage grants read access and lets issues and pull requests be managed.
+	PermissionTriage Permission = "triage"
+	// PermissionPush grants read and write access.
+	PermissionPush Permission = "push"
+	// PermissionMaintain grants write access and lets the repository be managed
+	// without access to sensitive or destructive actions.
+	PermissionMaintain Permission = "maintain"
+	// PermissionAdmin grants full access.
+	PermissionAdmin Permission = "admin"
+)
+
+var permissions = []Permission{PermissionPull, PermissionTriage, PermissionPush, PermissionMaintain, PermissionAdmin}
+
+// validateAccess checks the permissions of the teams and collaborators, and
+// that their names map to distinct resources.
+func validateAccess(teams, collaborators map[string]Permission) error {
+	for _, grants := range []struct {
+		kind   string
+		grants map[string]Permission
+	}{{"team", teams}, {"collaborator", collaborators}} {
+		suffixes := make(map[string]string)
+		for _, grantee := range slices.Sorted(maps.Keys(grants.grants)) {
+			suffix := slug(grantee)
+			if suffix == "" {
+				return fmt.Errorf("%s name %q must contain at least one letter or digit", grants.kind, grantee)
+			}
+			if other, ok := suffixes[suffix]; ok {
+				return fmt.Errorf("%ss %q and %q map to the same resource name", grants.kind, other, grantee)
+			}
+			suffixes[suffix] = grantee
+			if permission := grants.grants[grantee]; !slices.Contains(permissions, permission) {
+				return fmt.Errorf("%s %q has unknown permission %q", grants.kind, grantee, permission)
+			}
+		}
+	}
+	return nil
+}
+
+// newAccess grants the teams and collaborators access to the repository. In
+// authoritative mode a single github.RepositoryCollaborators resource manages
+// all access, revoking the access of every team and user that is not declared.
+// The grants remain individual resources as well, which are retained on
+// delete: a stack that switches to authoritative mode keeps them instead of
+// revoking the access that RepositoryCollaborators then manages. The
+// RepositoryCollaborators resource is retained on delete too, so that
+// switching back keeps the access.
+func newAccess(ctx *pulumi.Context, name string, repository pulumi.StringInput, teams, collaborators map[string]Permission, authoritative bool, opts ...pulumi.ResourceOption) error {
+	grantOpts := slices.Clone(opts)
+	if authoritative {
+		teamArgs := github.RepositoryCollaboratorsTeamArray{}
+		for _, team := range slices.Sorted(maps.Keys(teams)) {
+			teamArgs = append(teamArgs, &github.RepositoryCollaboratorsTeamArgs{
+				TeamId:     pulumi.String(team),
+				Permission: pulumi.String(string(teams[team])),
+			})
+		}
+		userArgs := github.RepositoryCollaboratorsUserArray{}
+		for _, user := range slices.Sorted(maps.Keys(collaborators)) {
+			userArgs = append(userArgs, &github.RepositoryCollaboratorsUserArgs{
+				Username:   pulumi.String(user),
+				Permission: pulumi.String(string(collaborators[user])),
+			})
+		}
+		_, err := github.NewRepositoryCollaborators(ctx, childName(name, "collaborators"), &github.RepositoryCollaboratorsArgs{
+			Repository: repository,
+			Teams:      teamArgs,
+			Users:      userArgs,
+		}, append(slices.Clone(opts), pulumi.RetainOnDelete(true))...)
+		if err != nil {
+			return err
+		}
+		grantOpts = append(grantOpts, pulumi.RetainOnDelete(true))
+	}
+
+	for _, team := range slices.Sorted(maps.Keys(teams)) {
+		_, err := github.NewTeamRepository(ctx, childName(name, "team-"+slug(team)), &github.TeamRepositoryArgs{
+			Repository: repository,
+			TeamId:     pulumi.String(team),
+			Permission: pulumi.String(string(teams[team])),
+		}, grantOpts...)
+		if err != nil {
+			return err
+		}
+	}
+	for _, user := range slices.Sorted(maps.Keys(collaborators)) {
+		_, err := github.NewRepositoryCollaborator(ctx, childName(name, "collaborator-"+slug(user)), &github.RepositoryCollaboratorArgs{
+			Repository: repository,
+			Username:   pulumi.String(user),
+			Permission: pulumi.String(string(collaborators[user])),
+		}, grantOpts...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package github_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/softwaredevelop/pulumi-go-components/components/github"
+)
+
+func TestNewStandardRepo_Access(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			Teams: map[string]github.Permission{
+				"platform":  github.PermissionMaintain,
+				"reviewers": github.PermissionTriage,
+			},
+			Collaborators: map[string]github.Permission{"octocat": github.PermissionPush},
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"repo-team-platform", "repo-team-reviewers"}, mocks.names("github:index/teamRepository:TeamRepository"))
+	assert.Equal(t, []string{"repo-collaborator-octocat"}, mocks.names("github:index/repositoryCollaborator:RepositoryCollaborator"))
+	assert.Empty(t, mocks.names("github:index/repositoryCollaborators:RepositoryCollaborators"))
+
+	team := mocks.inputs("repo-team-platform")
+	assert.Equal(t, "platform", team["teamId"].StringValue())
+	assert.Equal(t, "maintain", team["permission"].StringValue())
+	collaborator := mocks.inputs("repo-collaborator-octocat")
+	assert.Equal(t, "octocat", collaborator["username"].StringValue())
+	assert.Equal(t, "push", collaborator["permission"].StringValue())
+}
+
+func TestNewStandardRepo_AuthoritativeAccess(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName:      pulumi.String("test-repo"),
+			Teams:               map[string]github.Permission{"platform": github.PermissionAdmin},
+			Collaborators:       map[string]github.Permission{"octocat": github.PermissionPull, "hubot": github.PermissionPush},
+			AuthoritativeAccess: true,
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"repo-collaborators"}, mocks.names("github:index/repositoryCollaborators:RepositoryCollaborators"))
+	assert.True(t, mocks.retainOnDelete("repo-collaborators"), "the access should be kept when switching back")
+	grants := slices.Concat(
+		mocks.names("github:index/teamRepository:TeamRepository"),
+		mocks.names("github:index/repositoryCollaborator:RepositoryCollaborator"),
+	)
+	assert.ElementsMatch(t, []string{"repo-team-platform", "repo-collaborator-hubot", "repo-collaborator-octocat"}, grants)
+	for _, grant := range grants {
+		assert.True(t, mocks.retainOnDelete(grant), "%s should be retained, as RepositoryCollaborators manages the access", grant)
+	}
+
+	inputs := mocks.inputs("repo-collaborators")
+	assert.Len(t, inputs["teams"].ArrayValue(), 1)
+	users := inputs["users"].ArrayValue()
+	if assert.Len(t, users, 2) {
+		assert.Equal(t, "hubot", users[0].ObjectValue()["username"].StringValue(), "users should be sorted by login")
+	}
+}
+
+func TestNewStandardRepo_AuthoritativeAccessSwitch(t *testing.T) {
+	deploy := func(authoritative bool) *recordingMocks {
+		mocks := &recordingMocks{}
+		err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+			_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+				RepositoryName:      pulumi.String("test-repo"),
+				Teams:               map[string]github.Permission{"platform": github.PermissionAdmin},
+				Collaborators:       map[string]github.Permission{"octocat": github.PermissionPull},
+				AuthoritativeAccess: authoritative,
+			})
+			return err
+		}, pulumi.WithMocks("test-project", "test-stack", mocks))
+		assert.NoError(t, err)
+		return mocks
+	}
+	before, after := deploy(false), deploy(true)
+
+	// Every grant of the stack is still declared after the switch, so no
+	// access is revoked while RepositoryCollaborators takes over.
+	for _, typ := range []string{
+		"github:index/teamRepository:TeamRepository",
+		"github:index/repositoryCollaborator:RepositoryCollaborator",
+	} {
+		grants := before.names(typ)
+		assert.NotEmpty(t, grants)
+		assert.ElementsMatch(t, grants, after.names(typ))
+		for _, grant := range grants {
+			assert.False(t, before.retainOnDelete(grant), "%s should be deleted with the stack before the switch", grant)
+			assert.True(t, after.retainOnDelete(grant), "%s should be retained after the switch", grant)
+		}
+	}
+}
+
+func TestNewStandardRepo_InvalidAccess(t *testing.T) {
+	tests := []struct {
+		name          string
+		teams         map[string]github.Permission
+		collaborators map[string]github.Permission
+		expectedMsg   string
+	}{
+		{"UnknownTeamPermission", map[string]github.Permission{"platform": "write"}, nil, `team "platform" has unknown permission "write"`},
+		{"UnknownUserPermission", nil, map[string]github.Permission{"octocat": "owner"}, `collaborator "octocat" has unknown permission "owner"`},
+		{"SameResourceName", nil, map[string]github.Permission{"OctoCat": "pull", "octocat": "push"}, `collaborators "OctoCat" and "octocat" map to the same resource name`},
+		{"EmptyTeam", map[string]github.Permission{"": "pull"}, nil, `team name "" must contain at least one letter or digit`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName: pulumi.String("test-repo"),
+					Teams:          tt.teams,
+					Collaborators:  tt.collaborators,
+				})
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			assert.ErrorContains(t, err, tt.expectedMsg)
+			assert.Empty(t, mocks.resources, "nothing should be registered for invalid access")
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package github
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// AllowedActions selects the actions and reusable workflows a repository may run.
+type AllowedActions string
+
+const (
+	// AllowedActionsAll allows any action. It is the default when none is given.
+	AllowedActionsAll AllowedActions = "all"
+	// AllowedActionsLocalOnly allows only the actions defined in the repository itself.
+	AllowedActionsLocalOnly AllowedActions = "local_only"
+	// AllowedActionsSelected allows the local actions and the actions selected by
+	// the GitHub-owned and verified flags and the patterns of the policy.
+	AllowedActionsSelected AllowedActions = "selected"
+)
+
+var allowedActions = []AllowedActions{AllowedActionsAll, AllowedActionsLocalOnly, AllowedActionsSelected}
+
+// ActionsPolicy locks down GitHub Actions in the repository.
+//
+// The default GITHUB_TOKEN permissions and whether Actions can approve pull
+// requests are not covered, as the GitHub provider has no resource for the
+// workflow permissions of a repository.
+type ActionsPolicy struct {
+	// Enable GitHub Actions in the repository. Defaults to true.
+	Enabled *bool
+	// The actions the repository may run. Defaults to AllowedActionsAll.
+	AllowedActions AllowedActions
+	// Allow the actions owned by GitHub. Requires AllowedActionsSelected.
+	GitHubOwnedAllowed bool
+	// Allow the Marketplace actions of verified creators. Requires AllowedActionsSelected.
+	VerifiedAllowed bool
+	// The patterns of the allowed actions, such as "monalisa/octocat@*".
+	// Requires AllowedActionsSelected.
+	Patterns []string
+}
+
+// validateActionsPolicy checks that the selection settings are only given
+// together with AllowedActionsSelected, and not for disabled Actions.
+func validateActionsPolicy(policy *ActionsPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	if policy.AllowedActions != "" && !slices.Contains(allowedActions, policy.AllowedActions) {
+		return fmt.Errorf("unknown allowed actions %q", policy.AllowedActions)
+	}
+	if policy.Enabled != nil && !*policy.Enabled && policy.AllowedActions != "" {
+		return fmt.Errorf("allowed actions cannot be set when Actions are disabled")
+	}
+	selection := policy.GitHubOwnedAllowed || policy.VerifiedAllowed || len(policy.Patterns) > 0
+	if selection && policy.AllowedActions != AllowedActionsSelected {
+		return fmt.Errorf("allowed action patterns and flags require allowed actions %q", AllowedActionsSelected)
+	}
+	for _, pattern := range policy.Patterns {
+		if pattern == "" {
+			return fmt.Errorf("allowed action patterns must not be empty")
+		}
+	}
+	return nil
+}
+
+// newActionsPermissions applies the Actions policy to the repository.
+func newActionsPermissions(ctx *pulumi.Context, name string, repository pulumi.StringInput, policy *ActionsPolicy, opts ...pulumi.ResourceOption) error {
+	enabled := true
+	if policy.Enabled != nil {
+		enabled = *policy.Enabled
+	}
+	permissionsArgs := &github.ActionsRepositoryPermissionsArgs{
+		Repository: repository,
+		Enabled:    pulumi.Bool(enabled),
+	}
+	if enabled {
+		allowed := policy.AllowedActions
+		if allowed == "" {
+			allowed = AllowedActionsAll
+		}
+		permissionsArgs.AllowedActions = pulumi.String(string(allowed))
+		if allowed == AllowedActionsSelected {
+			permissionsArgs.AllowedActionsConfig = &github.ActionsRepositoryPermissionsAllowedActionsConfigArgs{
+				GithubOwnedAllowed: pulumi.Bool(policy.GitHubOwnedAllowed),
+				VerifiedAllowed:    pulumi.Bool(policy.VerifiedAllowed),
+				PatternsAlloweds:   pulumi.ToStringArray(policy.Patterns),
+			}
+		}
+	}
+	_, err := github.NewActionsRepositoryPermissions(ctx, childName(name, "actions-permissions"), permissionsArgs, opts...)
+	return err
+}
@@ -0,0 +1,122 @@
+package github_test
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/softwaredevelop/pulumi-go-components/components/github"
+)
+
+func TestNewStandardRepo_ActionsPolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		policy         *github.ActionsPolicy
+		enabled        bool
+		allowedActions string
+		config         map[string]any
+	}{
+		{
+			name:           "Defaults",
+			policy:         &github.ActionsPolicy{},
+			enabled:        true,
+			allowedActions: "all",
+		},
+		{
+			name:           "LocalOnly",
+			policy:         &github.ActionsPolicy{AllowedActions: github.AllowedActionsLocalOnly},
+			enabled:        true,
+			allowedActions: "local_only",
+		},
+		{
+			name: "Selected",
+			policy: &github.ActionsPolicy{
+				AllowedActions:     github.AllowedActionsSelected,
+				GitHubOwnedAllowed: true,
+				Patterns:           []string{"pulumi/*", "golangci/golangci-lint-action@v8"},
+			},
+			enabled:        true,
+			allowedActions: "selected",
+			config: map[string]any{
+				"githubOwnedAllowed": true,
+				"verifiedAllowed":    false,
+				"patternsAlloweds":   []any{"pulumi/*", "golangci/golangci-lint-action@v8"},
+			},
+		},
+		{
+			name:    "Disabled",
+			policy:  &github.ActionsPolicy{Enabled: ptr(false)},
+			enabled: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName: pulumi.String("test-repo"),
+					ActionsPolicy:  tt.policy,
+				})
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			assert.NoError(t, err)
+
+			permissions := mocks.inputs("repo-actions-permissions")
+			assert.Equal(t, tt.enabled, permissions["enabled"].BoolValue())
+			if tt.allowedActions == "" {
+				assert.NotContains(t, permissions, "allowedActions")
+			} else {
+				assert.Equal(t, tt.allowedActions, permissions["allowedActions"].StringValue())
+			}
+			if tt.config == nil {
+				assert.NotContains(t, permissions, "allowedActionsConfig")
+			} else {
+				assert.Equal(t, resource.NewPropertyMapFromMap(tt.config), permissions["allowedActionsConfig"].ObjectValue())
+			}
+		})
+	}
+}
+
+func TestNewStandardRepo_WithoutActionsPolicy(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+	assert.Empty(t, mocks.names("github:index/actionsRepositoryPermissions:ActionsRepositoryPermissions"))
+}
+
+func TestNewStandardRepo_InvalidActionsPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      *github.ActionsPolicy
+		expectedMsg string
+	}{
+		{"UnknownAllowedActions", &github.ActionsPolicy{AllowedActions: "localOnly"}, `unknown allowed actions "localOnly"`},
+		{"AllowedActionsWhenDisabled", &github.ActionsPolicy{Enabled: ptr(false), AllowedActions: github.AllowedActionsAll}, "allowed actions cannot be set when Actions are disabled"},
+		{"PatternsWithoutSelected", &github.ActionsPolicy{Patterns: []string{"pulumi/*"}}, `allowed action patterns and flags require allowed actions "selected"`},
+		{"VerifiedWithLocalOnly", &github.ActionsPolicy{AllowedActions: github.AllowedActionsLocalOnly, VerifiedAllowed: true}, `allowed action patterns and flags require allowed actions "selected"`},
+		{"EmptyPattern", &github.ActionsPolicy{AllowedActions: github.AllowedActionsSelected, Patterns: []string{""}}, "allowed action patterns must not be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName: pulumi.String("test-repo"),
+					ActionsPolicy:  tt.policy,
+				})
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			assert.ErrorContains(t, err, tt.expectedMsg)
+			assert.Empty(t, mocks.resources, "nothing should be registered for an invalid Actions policy")
+		})
+	}
+}
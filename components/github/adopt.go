@@ -0,0 +1,263 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// adoption holds the live state of an existing repository that is brought
+// under the component. It decides which children are imported: the
+// repository itself always, its branch protection and labels only if they
+// exist, so that missing ones are created instead.
+type adoption struct {
+	repositoryName pulumi.StringOutput
+	repository     github.LookupRepositoryResultOutput
+	labels         github.LookupIssueLabelsResultOutput
+	protections    github.GetBranchProtectionRulesResultOutput
+	// The security settings are missing from the repository lookup, so they
+	// are read from the REST API: the security and analysis settings from the
+	// repository, and the vulnerability alerts from their own endpoint.
+	restRepository      github.GetRestApiResultOutput
+	vulnerabilityAlerts github.GetRestApiResultOutput
+}
+
+// newAdoption looks up the live state of the repository.
+func newAdoption(ctx *pulumi.Context, repositoryName pulumi.StringInput, opts ...pulumi.InvokeOption) *adoption {
+	repository := github.LookupRepositoryOutput(ctx, github.LookupRepositoryOutputArgs{
+		Name: repositoryName,
+	}, opts...)
+	endpoint := func(suffix string) pulumi.StringOutput {
+		return repository.FullName().ApplyT(func(fullName string) string {
+			return "repos/" + fullName + suffix
+		}).(pulumi.StringOutput)
+	}
+	return &adoption{
+		repositoryName: repositoryName.ToStringOutput(),
+		repository:     repository,
+		labels: github.LookupIssueLabelsOutput(ctx, github.LookupIssueLabelsOutputArgs{
+			Repository: repositoryName,
+		}, opts...),
+		protections: github.GetBranchProtectionRulesOutput(ctx, github.GetBranchProtectionRulesOutputArgs{
+			Repository: repositoryName,
+		}, opts...),
+		restRepository: github.GetRestApiOutput(ctx, github.GetRestApiOutputArgs{
+			Endpoint: endpoint(""),
+		}, opts...),
+		vulnerabilityAlerts: github.GetRestApiOutput(ctx, github.GetRestApiOutputArgs{
+			Endpoint: endpoint("/vulnerability-alerts"),
+		}, opts...),
+	}
+}
+
+// importRepository imports the repository, whose ID is its name. The
+// github.IssueLabels resource of authoritative labels shares this ID.
+func (a *adoption) importRepository() pulumi.ResourceOption {
+	return pulumi.Import(a.repositoryName.ApplyT(func(repositoryName string) pulumi.ID {
+		return pulumi.ID(repositoryName)
+	}).(pulumi.IDOutput))
+}
+
+// importBranchProtection imports the protection rule of the pattern, if it exists.
+func (a *adoption) importBranchProtection(pattern string) pulumi.ResourceOption {
+	return pulumi.Import(pulumi.All(a.repositoryName, a.protections.Rules()).ApplyT(func(args []any) pulumi.ID {
+		for _, rule := range args[1].([]github.GetBranchProtectionRulesRule) {
+			if rule.Pattern == pattern {
+				return pulumi.ID(args[0].(string) + ":" + pattern)
+			}
+		}
+		return ""
+	}).(pulumi.IDOutput))
+}
+
+// importLabel imports the label, if it exists. Label names are case-insensitive.
+func (a *adoption) importLabel(labelName string) pulumi.ResourceOption {
+	return pulumi.Import(pulumi.All(a.repositoryName, a.labels.Labels()).ApplyT(func(args []any) pulumi.ID {
+		for _, label := range args[1].([]github.GetIssueLabelsLabel) {
+			if strings.EqualFold(label.Name, labelName) {
+				return pulumi.ID(args[0].(string) + ":" + labelName)
+			}
+		}
+		return ""
+	}).(pulumi.IDOutput))
+}
+
+// drift reports the settings where the live repository differs from the
+// standard. The import of a child fails as long as its settings differ, so
+// every difference is also logged as a warning on the component. The
+// description and the topics are only compared when they are declared.
+func (a *adoption) drift(ctx *pulumi.Context, component pulumi.Resource, policy RepositoryPolicy, merge MergePolicy, security SecuritySettings, branch string, labels []Label, description pulumi.StringInput, topics pulumi.StringArrayInput) pulumi.StringArrayOutput {
+	descriptionOutput := pulumi.ToOutput((*string)(nil)).(pulumi.StringPtrOutput)
+	if description != nil {
+		descriptionOutput = description.ToStringOutput().ToStringPtrOutput()
+	}
+	topicsOutput := pulumi.ToOutput(declaredTopics{})
+	if topics != nil {
+		topicsOutput = topics.ToStringArrayOutput().ApplyT(func(topics []string) declaredTopics {
+			return declaredTopics{declared: true, topics: topics}
+		})
+	}
+	return pulumi.All(a.repository, a.labels.Labels(), descriptionOutput, topicsOutput, a.restRepository, a.vulnerabilityAlerts).ApplyT(func(args []any) []string {
+		live := args[0].(github.LookupRepositoryResult)
+		drift := repositoryDrift(live, policy, merge, branch)
+		drift = append(drift, securityDrift(args[4].(github.GetRestApiResult), args[5].(github.GetRestApiResult), security)...)
+		drift = append(drift, descriptionDrift(live.Description, args[2].(*string))...)
+		drift = append(drift, topicsDrift(live.Topics, args[3].(declaredTopics))...)
+		drift = append(drift, labelDrift(args[1].([]github.GetIssueLabelsLabel), labels)...)
+		for _, difference := range drift {
+			_ = ctx.Log.Warn("adopted repository differs from the standard: "+difference, &pulumi.LogArgs{Resource: component})
+		}
+		return drift
+	}).(pulumi.StringArrayOutput)
+}
+
+// repositoryDrift compares the live settings of the repository with the policy,
+// the merge policy and the default branch.
+func repositoryDrift(live github.LookupRepositoryResult, policy RepositoryPolicy, merge MergePolicy, branch string) []string {
+	var drift []string
+	if policy.Visibility != nil && live.Visibility != *policy.Visibility {
+		drift = append(drift, fmt.Sprintf("visibility is %q, the standard is %q", live.Visibility, *policy.Visibility))
+	}
+	for _, setting := range []struct {
+		name     string
+		live     bool
+		standard *bool
+	}{
+		{"hasIssues", live.HasIssues, policy.HasIssues},
+		{"hasProjects", live.HasProjects, policy.HasProjects},
+		{"hasWiki", live.HasWiki, policy.HasWiki},
+		{"hasDiscussions", live.HasDiscussions, policy.HasDiscussions},
+		{"allowMergeCommit", live.AllowMergeCommit, merge.AllowMergeCommit},
+		{"allowSquashMerge", live.AllowSquashMerge, merge.AllowSquashMerge},
+		{"allowRebaseMerge", live.AllowRebaseMerge, merge.AllowRebaseMerge},
+		{"allowAutoMerge", live.AllowAutoMerge, merge.AllowAutoMerge},
+		{"allowUpdateBranch", live.AllowUpdateBranch, merge.AllowUpdateBranch},
+		{"deleteBranchOnMerge", live.DeleteBranchOnMerge, policy.DeleteBranchOnMerge},
+	} {
+		if setting.standard != nil && setting.live != *setting.standard {
+			drift = append(drift, fmt.Sprintf("%s is %t, the standard is %t", setting.name, setting.live, *setting.standard))
+		}
+	}
+	for _, setting := range []struct {
+		name           string
+		live, standard string
+	}{
+		{"mergeCommitTitle", live.MergeCommitTitle, merge.MergeCommitTitle},
+		{"mergeCommitMessage", live.MergeCommitMessage, merge.MergeCommitMessage},
+		{"squashMergeCommitTitle", live.SquashMergeCommitTitle, merge.SquashMergeCommitTitle},
+		{"squashMergeCommitMessage", live.SquashMergeCommitMessage, merge.SquashMergeCommitMessage},
+	} {
+		if setting.standard != "" && setting.live != setting.standard {
+			drift = append(drift, fmt.Sprintf("%s is %q, the standard is %q", setting.name, setting.live, setting.standard))
+		}
+	}
+	if live.DefaultBranch != branch {
+		drift = append(drift, fmt.Sprintf("defaultBranch is %q, the standard is %q", live.DefaultBranch, branch))
+	}
+	return drift
+}
+
+// securityDrift compares the live security settings with the resolved ones.
+// The security and analysis settings are only returned to administrators of
+// the repository, and a setting that cannot be read or is unmanaged is not
+// compared.
+func securityDrift(repository, vulnerabilityAlerts github.GetRestApiResult, security SecuritySettings) []string {
+	var drift []string
+	// GitHub answers 204 if the alerts are enabled and 404 if they are not.
+	alerts, known := false, true
+	switch vulnerabilityAlerts.Code {
+	case http.StatusNoContent:
+		alerts = true
+	case http.StatusNotFound:
+	default:
+		known = false
+	}
+	if known && security.VulnerabilityAlerts != nil && alerts != *security.VulnerabilityAlerts {
+		drift = append(drift, fmt.Sprintf("vulnerabilityAlerts is %t, the standard is %t", alerts, *security.VulnerabilityAlerts))
+	}
+
+	type analysisStatus struct {
+		Status string `json:"status"`
+	}
+	var live struct {
+		SecurityAndAnalysis map[string]analysisStatus `json:"security_and_analysis"`
+	}
+	if repository.Code != http.StatusOK || json.Unmarshal([]byte(repository.Body), &live) != nil {
+		return drift
+	}
+	for _, setting := range []struct {
+		name, key string
+		standard  *bool
+	}{
+		{"secretScanning", "secret_scanning", security.SecretScanning},
+		{"secretScanningPushProtection", "secret_scanning_push_protection", security.SecretScanningPushProtection},
+	} {
+		liveStatus, ok := live.SecurityAndAnalysis[setting.key]
+		if !ok || setting.standard == nil {
+			continue
+		}
+		if enabled := liveStatus.Status == "enabled"; enabled != *setting.standard {
+			drift = append(drift, fmt.Sprintf("%s is %t, the standard is %t", setting.name, enabled, *setting.standard))
+		}
+	}
+	return drift
+}
+
+// declaredTopics holds the topics of the arguments, which are only compared
+// with the live topics when they are declared.
+type declaredTopics struct {
+	declared bool
+	topics   []string
+}
+
+// descriptionDrift compares the live description with the declared one.
+func descriptionDrift(live, standard *string) []string {
+	if standard == nil {
+		return nil
+	}
+	liveDescription := ""
+	if live != nil {
+		liveDescription = *live
+	}
+	if liveDescription != *standard {
+		return []string{fmt.Sprintf("description is %q, the standard is %q", liveDescription, *standard)}
+	}
+	return nil
+}
+
+// topicsDrift compares the live topics with the declared ones, regardless of their order.
+func topicsDrift(live []string, standard declaredTopics) []string {
+	if !standard.declared {
+		return nil
+	}
+	liveTopics, standardTopics := slices.Sorted(slices.Values(live)), slices.Sorted(slices.Values(standard.topics))
+	if !slices.Equal(liveTopics, standardTopics) {
+		return []string{fmt.Sprintf("topics are %q, the standard is %q", liveTopics, standardTopics)}
+	}
+	return nil
+}
+
+// labelDrift compares the live labels with the declared labels of the same name.
+func labelDrift(live []github.GetIssueLabelsLabel, labels []Label) []string {
+	var drift []string
+	for _, label := range labels {
+		i := slices.IndexFunc(live, func(l github.GetIssueLabelsLabel) bool {
+			return strings.EqualFold(l.Name, label.Name)
+		})
+		if i < 0 {
+			continue
+		}
+		if !strings.EqualFold(live[i].Color, label.Color) {
+			drift = append(drift, fmt.Sprintf("label %q has color %q, the standard is %q", label.Name, live[i].Color, label.Color))
+		}
+		if live[i].Description != label.Description {
+			drift = append(drift, fmt.Sprintf("label %q has description %q, the standard is %q", label.Name, live[i].Description, label.Description))
+		}
+	}
+	return drift
+}
@@ -0,0 +1,278 @@
+package github_test
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/softwaredevelop/pulumi-go-components/components/github"
+)
+
+// adoptMocks answers the lookups of adopt mode with the live state of an
+// existing repository, and the REST API lookups with the responses of rest,
+// keyed by endpoint.
+type adoptMocks struct {
+	*recordingMocks
+
+	live map[string]map[string]any
+	rest map[string]map[string]any
+}
+
+// Call returns the live state recorded for the invoked function.
+func (m adoptMocks) Call(args pulumi.MockCallArgs) (resource.PropertyMap, error) {
+	if args.Token == "github:index/getRestApi:getRestApi" {
+		if response, ok := m.rest[args.Args["endpoint"].StringValue()]; ok {
+			return resource.NewPropertyMapFromMap(response), nil
+		}
+	}
+	if live, ok := m.live[args.Token]; ok {
+		return resource.NewPropertyMapFromMap(live), nil
+	}
+	return m.recordingMocks.Call(args)
+}
+
+// importID returns the import ID recorded for the resource with the given logical name.
+func (m *recordingMocks) importID(name string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range m.resources {
+		if r.Name == name && r.RegisterRPC != nil {
+			return r.RegisterRPC.GetImportId()
+		}
+	}
+	return ""
+}
+
+// liveRepository is a hand-made repository that follows the open-source
+// profile, except for its wiki and squash merge settings.
+var liveRepository = map[string]map[string]any{
+	"github:index/getRepository:getRepository": {
+		"name":                "hand-made",
+		"fullName":            "octocat/hand-made",
+		"description":         "A hand-made repository",
+		"topics":              []any{"pulumi", "go"},
+		"visibility":          "public",
+		"hasIssues":           true,
+		"hasProjects":         true,
+		"hasWiki":             true,
+		"hasDiscussions":      true,
+		"allowMergeCommit":    false,
+		"allowSquashMerge":    false,
+		"allowRebaseMerge":    true,
+		"deleteBranchOnMerge": true,
+		"defaultBranch":       "main",
+	},
+	"github:index/getIssueLabels:getIssueLabels": {
+		"repository": "hand-made",
+		"labels": []any{
+			map[string]any{"name": "bug", "color": "d73a4a", "description": "Something isn't working"},
+			map[string]any{"name": "Enhancement", "color": "A2EEEF", "description": "New feature"},
+		},
+	},
+	"github:index/getBranchProtectionRules:getBranchProtectionRules": {
+		"repository": "hand-made",
+		"rules":      []any{map[string]any{"pattern": "main"}},
+	},
+}
+
+// liveSecurity are the REST API responses of a hand-made repository without
+// vulnerability alerts and secret scanning.
+var liveSecurity = map[string]map[string]any{
+	"repos/octocat/hand-made": {
+		"code": 200,
+		"body": `{"security_and_analysis":{"secret_scanning":{"status":"disabled"},"secret_scanning_push_protection":{"status":"disabled"}}}`,
+	},
+	"repos/octocat/hand-made/vulnerability-alerts": {
+		"code": 404,
+	},
+}
+
+func TestNewStandardRepo_Adopt(t *testing.T) {
+	mocks := adoptMocks{recordingMocks: &recordingMocks{}, live: liveRepository}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		repo, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("hand-made"),
+			Profile:        github.ProfileOpenSource,
+			LabelCatalogs:  []github.LabelCatalog{github.LabelCatalogTriage},
+			Adopt:          true,
+		})
+		if err != nil {
+			return err
+		}
+
+		assertOutputEquals(t, repo.Drift, []string{
+			"hasWiki is true, the standard is false",
+			"allowSquashMerge is false, the standard is true",
+			`label "enhancement" has description "New feature", the standard is "New feature or request"`,
+		})
+		return nil
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "hand-made", mocks.importID("repo-repository"))
+	assert.Equal(t, "hand-made:main", mocks.importID("repo-branch-protection"))
+
+	// Existing labels are imported, missing ones are created.
+	assert.Equal(t, "hand-made:bug", mocks.importID("repo-label-bug"))
+	assert.Equal(t, "hand-made:enhancement", mocks.importID("repo-label-enhancement"))
+	assert.Empty(t, mocks.importID("repo-label-question"))
+
+	// The initial commit of an adopted repository already exists.
+	assert.NotContains(t, mocks.inputs("repo-repository"), "autoInit")
+}
+
+func TestNewStandardRepo_AdoptDescriptionAndTopics(t *testing.T) {
+	tests := []struct {
+		name          string
+		description   pulumi.StringInput
+		topics        pulumi.StringArrayInput
+		expectedDrift []string
+	}{
+		{"Matching", pulumi.String("A hand-made repository"), pulumi.ToStringArray([]string{"go", "pulumi"}), nil},
+		{"Differing", pulumi.String("A standard repository").ToStringOutput(), pulumi.ToStringArray([]string{"go"}), []string{
+			`description is "A hand-made repository", the standard is "A standard repository"`,
+			`topics are ["go" "pulumi"], the standard is ["go"]`,
+		}},
+		{"Undeclared", nil, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := adoptMocks{recordingMocks: &recordingMocks{}, live: liveRepository}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				repo, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName: pulumi.String("hand-made"),
+					Description:    tt.description,
+					Topics:         tt.topics,
+					Adopt:          true,
+				})
+				if err != nil {
+					return err
+				}
+
+				repo.Drift.ApplyT(func(drift []string) error {
+					assert.Equal(t, tt.expectedDrift, drift)
+					return nil
+				})
+				return nil
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewStandardRepo_AdoptSecurity(t *testing.T) {
+	tests := []struct {
+		name          string
+		profile       github.RepositoryProfile
+		security      *github.SecuritySettings
+		rest          map[string]map[string]any
+		expectedDrift []string
+	}{
+		{"Differing", github.ProfileOpenSource, nil, liveSecurity, []string{
+			"vulnerabilityAlerts is false, the standard is true",
+			"secretScanning is false, the standard is true",
+			"secretScanningPushProtection is false, the standard is true",
+		}},
+		{"Matching", github.ProfileOpenSource, &github.SecuritySettings{SecretScanning: ptr(false), VulnerabilityAlerts: ptr(false)}, liveSecurity, nil},
+		// The settings are not compared when they cannot be read.
+		{"Unreadable", github.ProfileOpenSource, nil, nil, nil},
+		// Nor when they are not managed.
+		{"Unmanaged", "", nil, liveSecurity, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := adoptMocks{recordingMocks: &recordingMocks{}, live: liveRepository, rest: tt.rest}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				repo, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName: pulumi.String("hand-made"),
+					Profile:        tt.profile,
+					Policy:         &github.RepositoryPolicy{HasWiki: ptr(true), AllowSquashMerge: ptr(false)},
+					Security:       tt.security,
+					Adopt:          true,
+				})
+				if err != nil {
+					return err
+				}
+
+				repo.Drift.ApplyT(func(drift []string) error {
+					assert.Equal(t, tt.expectedDrift, drift)
+					return nil
+				})
+				return nil
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewStandardRepo_AdoptWithArchiveLifecycle(t *testing.T) {
+	mocks := adoptMocks{recordingMocks: &recordingMocks{}, live: liveRepository}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("hand-made"),
+			Lifecycle:      github.LifecycleArchive,
+			Adopt:          true,
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.ErrorContains(t, err, `lifecycle "archive" does not apply to adopted repositories`)
+	assert.Empty(t, mocks.resources)
+}
+
+func TestNewStandardRepo_AdoptWithoutBranchProtection(t *testing.T) {
+	live := map[string]map[string]any{}
+	for token, state := range liveRepository {
+		live[token] = state
+	}
+	live["github:index/getBranchProtectionRules:getBranchProtectionRules"] = map[string]any{
+		"repository": "hand-made",
+		"rules":      []any{},
+	}
+
+	mocks := adoptMocks{recordingMocks: &recordingMocks{}, live: live}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName:      pulumi.String("hand-made"),
+			AuthoritativeLabels: true,
+			Adopt:               true,
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	assert.Empty(t, mocks.importID("repo-branch-protection"), "a missing protection rule should be created")
+	assert.Equal(t, "hand-made", mocks.importID("repo-labels"))
+}
+
+func TestNewStandardRepo_WithoutAdopt(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	for _, r := range mocks.resources {
+		assert.Empty(t, mocks.importID(r.Name), "%s should not be imported", r.Name)
+	}
+}
+
+func TestNewStandardRepo_AdoptWithAutoInit(t *testing.T) {
+	mocks := adoptMocks{recordingMocks: &recordingMocks{}, live: liveRepository}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("hand-made"),
+			AutoInit:       ptr(true),
+			Adopt:          true,
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.ErrorContains(t, err, "AutoInit does not apply to adopted repositories")
+	assert.Empty(t, mocks.resources)
+}
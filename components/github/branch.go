@@ -0,0 +1,67 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// defaultBranch is the default branch of the repository unless
+// StandardRepoArgs.DefaultBranch is set. It is also the branch that AutoInit
+// is assumed to create, as GitHub names the initial branch after the owner's
+// default branch setting, which is main unless the owner changed it.
+const defaultBranch = "main"
+
+// validateInitialization checks the default branch name, and that the
+// templates are only requested together with AutoInit. Adopted repositories
+// already have their initial commit.
+func validateInitialization(adopt, autoInit bool, branch, gitignoreTemplate, licenseTemplate string) error {
+	if err := validateBranchName(branch); err != nil {
+		return err
+	}
+	if adopt && autoInit {
+		return fmt.Errorf("AutoInit does not apply to adopted repositories")
+	}
+	if !autoInit && (gitignoreTemplate != "" || licenseTemplate != "") {
+		return fmt.Errorf("gitignore and license templates require AutoInit")
+	}
+	return nil
+}
+
+// validateBranchName checks the name against the rules of git check-ref-format
+// that apply to branch names.
+func validateBranchName(branch string) error {
+	switch {
+	case branch == "":
+		return fmt.Errorf("default branch name must not be empty")
+	case strings.HasPrefix(branch, "-"), strings.HasPrefix(branch, "/"), strings.HasSuffix(branch, "/"),
+		strings.HasSuffix(branch, "."), strings.HasSuffix(branch, ".lock"), branch == "@",
+		strings.Contains(branch, ".."), strings.Contains(branch, "//"), strings.Contains(branch, "@{"),
+		strings.Contains(branch, "/."), strings.HasPrefix(branch, "."),
+		strings.ContainsAny(branch, " ~^:?*[\\\t\n\x7f"):
+		return fmt.Errorf("default branch name %q is not a valid git branch name", branch)
+	}
+	for _, r := range branch {
+		if r < ' ' {
+			return fmt.Errorf("default branch name %q is not a valid git branch name", branch)
+		}
+	}
+	return nil
+}
+
+// newDefaultBranch makes the branch the default branch of the repository.
+// When the component creates the initial commit, any branch other than main
+// is created by renaming the initial branch, whatever the owner's setting
+// named it. An owner whose setting already names the initial branch after
+// DefaultBranch should therefore create the repository without AutoInit.
+// Otherwise the branch must already exist, as the component did not create
+// it, so it is selected without renaming anything.
+func newDefaultBranch(ctx *pulumi.Context, name string, repository pulumi.StringInput, branch string, autoInit bool, opts ...pulumi.ResourceOption) (*github.BranchDefault, error) {
+	return github.NewBranchDefault(ctx, childName(name, "default-branch"), &github.BranchDefaultArgs{
+		Repository: repository,
+		Branch:     pulumi.String(branch),
+		Rename:     pulumi.Bool(autoInit && branch != defaultBranch),
+	}, opts...)
+}
@@ -0,0 +1,155 @@
+package github_test
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/softwaredevelop/pulumi-go-components/components/github"
+)
+
+func TestNewStandardRepo_UnmanagedDefaultBranch(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			Files:          []github.File{github.EditorConfigFile()},
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	// Without AutoInit or DefaultBranch, the initialization and the default
+	// branch are left as they were before the component managed them.
+	assert.NotContains(t, mocks.inputs("repo-repository"), resource.PropertyKey("autoInit"))
+	assert.Empty(t, mocks.names("github:index/branchDefault:BranchDefault"))
+	assert.Equal(t, "main", mocks.inputs("repo-branch-protection")["pattern"].StringValue())
+	assert.Equal(t, "main", mocks.inputs("repo-file-editorconfig")["branch"].StringValue())
+}
+
+func TestNewStandardRepo_DefaultBranch(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			AutoInit:       ptr(true),
+			Files:          []github.File{github.EditorConfigFile()},
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	repository := mocks.inputs("repo-repository")
+	assert.True(t, repository["autoInit"].BoolValue())
+	assert.NotContains(t, repository, "gitignoreTemplate")
+	assert.NotContains(t, repository, "licenseTemplate")
+
+	branchDefault := mocks.inputs("repo-default-branch")
+	assert.Equal(t, "main", branchDefault["branch"].StringValue())
+	assert.False(t, branchDefault["rename"].BoolValue())
+
+	// Branch protection must not be applied before the default branch exists.
+	assert.Equal(t, "main", mocks.inputs("repo-branch-protection")["pattern"].StringValue())
+	assert.True(t, mocks.dependsOn("repo-branch-protection", "repo-default-branch"))
+
+	assert.Equal(t, "main", mocks.inputs("repo-file-editorconfig")["branch"].StringValue())
+}
+
+func TestNewStandardRepo_CustomDefaultBranch(t *testing.T) {
+	tests := []struct {
+		name           string
+		protectionMode github.ProtectionMode
+		protectionName string
+	}{
+		{"BranchProtection", github.ProtectionBranchProtection, "repo-branch-protection"},
+		{"Ruleset", github.ProtectionRuleset, "repo-ruleset"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName:    pulumi.String("test-repo"),
+					AutoInit:          ptr(true),
+					GitignoreTemplate: "Go",
+					LicenseTemplate:   "mit",
+					DefaultBranch:     "trunk",
+					ProtectionMode:    tt.protectionMode,
+					Files:             []github.File{github.EditorConfigFile()},
+				})
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			assert.NoError(t, err)
+
+			repository := mocks.inputs("repo-repository")
+			assert.Equal(t, "Go", repository["gitignoreTemplate"].StringValue())
+			assert.Equal(t, "mit", repository["licenseTemplate"].StringValue())
+
+			branchDefault := mocks.inputs("repo-default-branch")
+			assert.Equal(t, "trunk", branchDefault["branch"].StringValue())
+			assert.True(t, branchDefault["rename"].BoolValue(), "the initial branch should be renamed")
+
+			assert.True(t, mocks.dependsOn(tt.protectionName, "repo-default-branch"))
+			assert.Equal(t, "trunk", mocks.inputs("repo-file-editorconfig")["branch"].StringValue())
+		})
+	}
+}
+
+func TestNewStandardRepo_ExistingDefaultBranch(t *testing.T) {
+	tests := []struct {
+		name string
+		args *github.StandardRepoArgs
+	}{
+		{"Adopt", &github.StandardRepoArgs{Adopt: true}},
+		{"AutoInitUnset", &github.StandardRepoArgs{}},
+		{"WithoutAutoInit", &github.StandardRepoArgs{AutoInit: ptr(false)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.args.RepositoryName = pulumi.String("test-repo")
+			tt.args.DefaultBranch = "master"
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", tt.args)
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			assert.NoError(t, err)
+
+			// The component did not create the branch, so it must not rename it.
+			branchDefault := mocks.inputs("repo-default-branch")
+			assert.Equal(t, "master", branchDefault["branch"].StringValue())
+			assert.False(t, branchDefault["rename"].BoolValue())
+		})
+	}
+}
+
+func TestNewStandardRepo_InvalidInitialization(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        github.StandardRepoArgs
+		expectedMsg string
+	}{
+		{"BranchWithSpace", github.StandardRepoArgs{DefaultBranch: "my branch"}, `default branch name "my branch" is not a valid git branch name`},
+		{"BranchWithDoubleDot", github.StandardRepoArgs{DefaultBranch: "release..1"}, `default branch name "release..1" is not a valid git branch name`},
+		{"BranchEndingInLock", github.StandardRepoArgs{DefaultBranch: "main.lock"}, `default branch name "main.lock" is not a valid git branch name`},
+		{"TemplateWithoutAutoInit", github.StandardRepoArgs{AutoInit: ptr(false), LicenseTemplate: "mit"}, "gitignore and license templates require AutoInit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				args := tt.args
+				args.RepositoryName = pulumi.String("test-repo")
+				_, err := github.NewStandardRepo(ctx, "repo", &args)
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			assert.ErrorContains(t, err, tt.expectedMsg)
+			assert.Empty(t, mocks.resources, "nothing should be registered for an invalid initialization")
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package github
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/provider"
+)
+
+// StandardRepoType is the type token of the StandardRepo component. Its module
+// carries the major version of the component's resource layout, like the API
+// versions of Kubernetes, so that a restructure that would replace resources
+// is published under a new version that aliases the previous ones.
+const StandardRepoType = "softwaredevelop:github/v1:StandardRepo"
+
+// legacyStandardRepoTypes are the type tokens the component was released
+// with before, oldest first. Existing stacks migrate from any of them without
+// replacement, as the aliases of the component apply to its children.
+var legacyStandardRepoTypes = []string{
+	"custom:resource:StandardRepo",
+}
+
+// typeAliases returns the aliases of a component to its legacy type tokens.
+func typeAliases(legacyTypes []string) pulumi.ResourceOption {
+	aliases := make([]pulumi.Alias, 0, len(legacyTypes))
+	for _, legacyType := range legacyTypes {
+		aliases = append(aliases, pulumi.Alias{Type: pulumi.String(legacyType)})
+	}
+	return pulumi.Aliases(aliases)
+}
+
+// standardRepoInputs holds the inputs of StandardRepo that other languages can
+// set through the component provider. Policy, MergePolicy, Ruleset,
+// Environments, Webhooks, ActionsPolicy, Files, FileCommitAuthor, Security and
+// the resource options, provider and transforms of the children are only
+// available from Go, as the schema description states.
+type standardRepoInputs struct {
+	RepositoryName         pulumi.StringInput            `pulumi:"repositoryName"`
+	Description            pulumi.StringInput            `pulumi:"description"`
+	Topics                 pulumi.StringArrayInput       `pulumi:"topics"`
+	LegacyChildNames       bool                          `pulumi:"legacyChildNames"`
+	NormalizeTopics        bool                          `pulumi:"normalizeTopics"`
+	AutoInit               *bool                         `pulumi:"autoInit"`
+	GitignoreTemplate      string                        `pulumi:"gitignoreTemplate"`
+	LicenseTemplate        string                        `pulumi:"licenseTemplate"`
+	DefaultBranch          string                        `pulumi:"defaultBranch"`
+	Profile                RepositoryProfile             `pulumi:"profile"`
+	ProtectionMode         ProtectionMode                `pulumi:"protectionMode"`
+	Labels                 []Label                       `pulumi:"labels"`
+	LabelCatalogs          []LabelCatalog                `pulumi:"labelCatalogs"`
+	AuthoritativeLabels    bool                          `pulumi:"authoritativeLabels"`
+	Secrets                map[string]pulumi.StringInput `pulumi:"secrets"`
+	EncryptSecrets         bool                          `pulumi:"encryptSecrets"`
+	StableSealedSecrets    []string                      `pulumi:"stableSealedSecrets"`
+	Variables              map[string]pulumi.StringInput `pulumi:"variables"`
+	Teams                  map[string]Permission         `pulumi:"teams"`
+	Collaborators          map[string]Permission         `pulumi:"collaborators"`
+	AuthoritativeAccess    bool                          `pulumi:"authoritativeAccess"`
+	Lifecycle              Lifecycle                     `pulumi:"lifecycle"`
+	ProtectChildren        bool                          `pulumi:"protectChildren"`
+	RetainChildrenOnDelete bool                          `pulumi:"retainChildrenOnDelete"`
+	OverwriteFilesOnCreate bool                          `pulumi:"overwriteFilesOnCreate"`
+	Dependabot             []DependabotUpdate            `pulumi:"dependabot"`
+	Adopt                  bool                          `pulumi:"adopt"`
+}
+
+// args converts the inputs into the arguments of NewStandardRepo.
+func (in *standardRepoInputs) args() *StandardRepoArgs {
+	return &StandardRepoArgs{
+		RepositoryName:         in.RepositoryName,
+		Description:            in.Description,
+		Topics:                 in.Topics,
+		LegacyChildNames:       in.LegacyChildNames,
+		NormalizeTopics:        in.NormalizeTopics,
+		AutoInit:               in.AutoInit,
+		GitignoreTemplate:      in.GitignoreTemplate,
+		LicenseTemplate:        in.LicenseTemplate,
+		DefaultBranch:          in.DefaultBranch,
+		Profile:                in.Profile,
+		ProtectionMode:         in.ProtectionMode,
+		Labels:                 in.Labels,
+		LabelCatalogs:          in.LabelCatalogs,
+		AuthoritativeLabels:    in.AuthoritativeLabels,
+		Secrets:                in.Secrets,
+		EncryptSecrets:         in.EncryptSecrets,
+		StableSealedSecrets:    in.StableSealedSecrets,
+		Variables:              in.Variables,
+		Teams:                  in.Teams,
+		Collaborators:          in.Collaborators,
+		AuthoritativeAccess:    in.AuthoritativeAccess,
+		Lifecycle:              in.Lifecycle,
+		ProtectChildren:        in.ProtectChildren,
+		RetainChildrenOnDelete: in.RetainChildrenOnDelete,
+		OverwriteFilesOnCreate: in.OverwriteFilesOnCreate,
+		Dependabot:             in.Dependabot,
+		Adopt:                  in.Adopt,
+	}
+}
+
+// Construct creates the component of the given type token for a program
+// written in another language. It is called by the component provider.
+func Construct(ctx *pulumi.Context, typ, name string, inputs provider.ConstructInputs, options pulumi.ResourceOption) (*provider.ConstructResult, error) {
+	switch typ {
+	case StandardRepoType:
+		var in standardRepoInputs
+		if err := inputs.CopyTo(&in); err != nil {
+			return nil, fmt.Errorf("reading the inputs of %s: %w", name, err)
+		}
+		standardRepo, err := NewStandardRepo(ctx, name, in.args(), options)
+		if err != nil {
+			return nil, err
+		}
+		return &provider.ConstructResult{URN: standardRepo.URN(), State: standardRepo.outputs()}, nil
+	}
+	return nil, fmt.Errorf("unknown resource type %q", typ)
+}
@@ -0,0 +1,38 @@
+package github
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStandardRepoInputs_Schema checks that Construct reads exactly the inputs
+// that the golden schema.json of the provider declares, and that the secrets
+// are marked as secret there.
+func TestStandardRepoInputs_Schema(t *testing.T) {
+	golden, err := os.ReadFile("../../provider/schema.json")
+	require.NoError(t, err)
+	var schema struct {
+		Resources map[string]struct {
+			InputProperties map[string]struct {
+				Secret bool `json:"secret"`
+			} `json:"inputProperties"`
+		} `json:"resources"`
+	}
+	require.NoError(t, json.Unmarshal(golden, &schema))
+	properties := schema.Resources[StandardRepoType].InputProperties
+
+	inputs := reflect.TypeFor[standardRepoInputs]()
+	var tags []string
+	for i := range inputs.NumField() {
+		tags = append(tags, inputs.Field(i).Tag.Get("pulumi"))
+	}
+	assert.ElementsMatch(t, slices.Collect(maps.Keys(properties)), tags)
+	assert.True(t, properties["secrets"].Secret, "the secrets input should be marked as secret")
+}
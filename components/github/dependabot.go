@@ -0,0 +1,191 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// dependabotConfigPath is the path of the rendered Dependabot configuration.
+const dependabotConfigPath = ".github/dependabot.yml"
+
+// DependabotUpdate defines how Dependabot keeps the dependencies of a package
+// ecosystem up to date.
+type DependabotUpdate struct {
+	// The package ecosystem, such as "gomod", "github-actions" or "npm".
+	Ecosystem string `pulumi:"ecosystem"`
+	// The directory of the package manifests, relative to the repository root. Defaults to "/".
+	Directory string `pulumi:"directory"`
+	// How often to check for updates: "daily", "weekly", "monthly", "quarterly",
+	// "semiannually" or "yearly". Defaults to "weekly".
+	Interval string `pulumi:"interval"`
+	// The day of the week weekly updates run on, such as "monday".
+	Day string `pulumi:"day"`
+	// The time of day updates run at, in "hh:mm" format.
+	Time string `pulumi:"time"`
+	// The time zone of Time, such as "Europe/Budapest". Defaults to UTC.
+	TimeZone string `pulumi:"timeZone"`
+	// The maximum number of open pull requests. Zero keeps Dependabot's default of 5.
+	OpenPullRequestsLimit int `pulumi:"openPullRequestsLimit"`
+}
+
+var dependabotEcosystems = []string{
+	"bun",
+	"bundler",
+	"cargo",
+	"composer",
+	"devcontainers",
+	"docker",
+	"docker-compose",
+	"dotnet-sdk",
+	"elm",
+	"github-actions",
+	"gitsubmodule",
+	"gomod",
+	"gradle",
+	"helm",
+	"maven",
+	"mix",
+	"npm",
+	"nuget",
+	"pip",
+	"pub",
+	"swift",
+	"terraform",
+	"uv",
+}
+
+var dependabotIntervals = []string{"daily", "weekly", "monthly", "quarterly", "semiannually", "yearly"}
+
+var weekdays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+var dependabotTimePattern = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+// dependabotEcosystemLabels maps the ecosystems that have a dedicated label in
+// the dependabot catalog to that label. The pull requests of every other
+// ecosystem are labelled "dependencies".
+var dependabotEcosystemLabels = map[string]string{
+	"github-actions": githubActionsLabel.Name,
+	"gomod":          "go-modules dependencies",
+}
+
+// validateDependabot checks the ecosystems, directories and schedules of the updates.
+func validateDependabot(updates []DependabotUpdate) error {
+	seen := make(map[string]bool)
+	for _, update := range updates {
+		if !slices.Contains(dependabotEcosystems, update.Ecosystem) {
+			return fmt.Errorf("unknown Dependabot package ecosystem %q", update.Ecosystem)
+		}
+		directory := dependabotDirectory(update)
+		if !strings.HasPrefix(directory, "/") {
+			return fmt.Errorf("dependabot directory %q of %q must start with '/'", directory, update.Ecosystem)
+		}
+		key := update.Ecosystem + " " + directory
+		if seen[key] {
+			return fmt.Errorf("dependabot updates for %q in %q are declared more than once", update.Ecosystem, directory)
+		}
+		seen[key] = true
+
+		interval := dependabotInterval(update)
+		if !slices.Contains(dependabotIntervals, interval) {
+			return fmt.Errorf("dependabot updates for %q have unknown interval %q", update.Ecosystem, interval)
+		}
+		if update.Day != "" {
+			if interval != "weekly" {
+				return fmt.Errorf("dependabot updates for %q can only set a day with the weekly interval", update.Ecosystem)
+			}
+			if !slices.Contains(weekdays, update.Day) {
+				return fmt.Errorf("dependabot updates for %q have unknown day %q", update.Ecosystem, update.Day)
+			}
+		}
+		if update.Time != "" && !dependabotTimePattern.MatchString(update.Time) {
+			return fmt.Errorf("dependabot updates for %q have invalid time %q, expected hh:mm", update.Ecosystem, update.Time)
+		}
+		if update.TimeZone != "" && update.Time == "" {
+			return fmt.Errorf("dependabot updates for %q set a time zone without a time", update.Ecosystem)
+		}
+		if update.OpenPullRequestsLimit < 0 {
+			return fmt.Errorf("dependabot updates for %q have a negative open pull requests limit", update.Ecosystem)
+		}
+	}
+	return nil
+}
+
+// withDependabotLabels adds the catalog labels referenced by the updates to the
+// labels, unless a label of the same name is already declared.
+func withDependabotLabels(labels []Label, updates []DependabotUpdate) []Label {
+	declared := make(map[string]bool)
+	for _, label := range labels {
+		declared[strings.ToLower(label.Name)] = true
+	}
+	for _, update := range updates {
+		labelName := dependabotLabel(update.Ecosystem)
+		if declared[strings.ToLower(labelName)] {
+			continue
+		}
+		for _, label := range labelCatalogs[LabelCatalogDependabot] {
+			if label.Name == labelName {
+				labels = append(labels, label)
+				declared[strings.ToLower(labelName)] = true
+			}
+		}
+	}
+	return labels
+}
+
+// dependabotFile renders the .github/dependabot.yml of the updates.
+func dependabotFile(updates []DependabotUpdate) File {
+	var b strings.Builder
+	b.WriteString("# This file is managed by Pulumi. Manual changes are overwritten.\n")
+	b.WriteString("version: 2\n")
+	b.WriteString("updates:\n")
+	for _, update := range updates {
+		fmt.Fprintf(&b, "  - package-ecosystem: %s\n", strconv.Quote(update.Ecosystem))
+		fmt.Fprintf(&b, "    directory: %s\n", strconv.Quote(dependabotDirectory(update)))
+		b.WriteString("    schedule:\n")
+		fmt.Fprintf(&b, "      interval: %s\n", strconv.Quote(dependabotInterval(update)))
+		if update.Day != "" {
+			fmt.Fprintf(&b, "      day: %s\n", strconv.Quote(update.Day))
+		}
+		if update.Time != "" {
+			fmt.Fprintf(&b, "      time: %s\n", strconv.Quote(update.Time))
+		}
+		if update.TimeZone != "" {
+			fmt.Fprintf(&b, "      timezone: %s\n", strconv.Quote(update.TimeZone))
+		}
+		if update.OpenPullRequestsLimit > 0 {
+			fmt.Fprintf(&b, "    open-pull-requests-limit: %d\n", update.OpenPullRequestsLimit)
+		}
+		b.WriteString("    labels:\n")
+		fmt.Fprintf(&b, "      - %s\n", strconv.Quote(dependabotLabel(update.Ecosystem)))
+	}
+	return File{
+		Path:    dependabotConfigPath,
+		Content: pulumi.String(b.String()),
+	}
+}
+
+func dependabotLabel(ecosystem string) string {
+	if label, ok := dependabotEcosystemLabels[ecosystem]; ok {
+		return label
+	}
+	return "dependencies"
+}
+
+func dependabotDirectory(update DependabotUpdate) string {
+	if update.Directory == "" {
+		return "/"
+	}
+	return update.Directory
+}
+
+func dependabotInterval(update DependabotUpdate) string {
+	if update.Interval == "" {
+		return "weekly"
+	}
+	return update.Interval
+}
@@ -0,0 +1,127 @@
+package github_test
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/softwaredevelop/pulumi-go-components/components/github"
+)
+
+func TestNewStandardRepo_Dependabot(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			Dependabot: []github.DependabotUpdate{
+				{Ecosystem: "github-actions"},
+				{Ecosystem: "gomod", Directory: "/iac", Interval: "weekly", Day: "monday", Time: "06:00", TimeZone: "Europe/Budapest"},
+				{Ecosystem: "npm", Interval: "daily", OpenPullRequestsLimit: 10},
+			},
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	expected := `# This file is managed by Pulumi. Manual changes are overwritten.
+version: 2
+updates:
+  - package-ecosystem: "github-actions"
+    directory: "/"
+    schedule:
+      interval: "weekly"
+    labels:
+      - "github-actions dependencies"
+  - package-ecosystem: "gomod"
+    directory: "/iac"
+    schedule:
+      interval: "weekly"
+      day: "monday"
+      time: "06:00"
+      timezone: "Europe/Budapest"
+    labels:
+      - "go-modules dependencies"
+  - package-ecosystem: "npm"
+    directory: "/"
+    schedule:
+      interval: "daily"
+    open-pull-requests-limit: 10
+    labels:
+      - "dependencies"
+`
+	config := mocks.inputs("repo-file-github-dependabot-yml")
+	assert.Equal(t, ".github/dependabot.yml", config["file"].StringValue())
+	assert.Equal(t, expected, config["content"].StringValue())
+
+	// Every label referenced by the configuration is created.
+	assert.Equal(t, []string{
+		"repo-label-dependencies",
+		"repo-label-gh-actions",
+		"repo-label-go-modules-dependencies",
+	}, mocks.names("github:index/issueLabel:IssueLabel"))
+}
+
+func TestNewStandardRepo_DependabotKeepsDeclaredLabels(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			Labels:         []github.Label{{Name: "go-modules dependencies", Color: "00ADD8"}},
+			Dependabot:     []github.DependabotUpdate{{Ecosystem: "gomod"}},
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"repo-label-go-modules-dependencies"}, mocks.names("github:index/issueLabel:IssueLabel"))
+	assert.Equal(t, "00ADD8", mocks.inputs("repo-label-go-modules-dependencies")["color"].StringValue())
+}
+
+func TestNewStandardRepo_WithoutDependabot(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	assert.Empty(t, mocks.names("github:index/repositoryFile:RepositoryFile"))
+}
+
+func TestNewStandardRepo_InvalidDependabot(t *testing.T) {
+	tests := []struct {
+		name        string
+		updates     []github.DependabotUpdate
+		files       []github.File
+		expectedMsg string
+	}{
+		{"UnknownEcosystem", []github.DependabotUpdate{{Ecosystem: "go"}}, nil, `unknown Dependabot package ecosystem "go"`},
+		{"RelativeDirectory", []github.DependabotUpdate{{Ecosystem: "gomod", Directory: "iac"}}, nil, `dependabot directory "iac" of "gomod" must start with '/'`},
+		{"Duplicate", []github.DependabotUpdate{{Ecosystem: "gomod"}, {Ecosystem: "gomod", Directory: "/"}}, nil, `dependabot updates for "gomod" in "/" are declared more than once`},
+		{"UnknownInterval", []github.DependabotUpdate{{Ecosystem: "gomod", Interval: "hourly"}}, nil, `dependabot updates for "gomod" have unknown interval "hourly"`},
+		{"DayWithoutWeekly", []github.DependabotUpdate{{Ecosystem: "gomod", Interval: "daily", Day: "monday"}}, nil, "can only set a day with the weekly interval"},
+		{"UnknownDay", []github.DependabotUpdate{{Ecosystem: "gomod", Day: "Monday"}}, nil, `unknown day "Monday"`},
+		{"InvalidTime", []github.DependabotUpdate{{Ecosystem: "gomod", Time: "6:00"}}, nil, `invalid time "6:00"`},
+		{"TimeZoneWithoutTime", []github.DependabotUpdate{{Ecosystem: "gomod", TimeZone: "UTC"}}, nil, "set a time zone without a time"},
+		{"ConflictingFile", []github.DependabotUpdate{{Ecosystem: "gomod"}}, []github.File{{Path: ".github/dependabot.yml", Content: pulumi.String("version: 2\n")}}, `files ".github/dependabot.yml" and ".github/dependabot.yml" map to the same resource name`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName: pulumi.String("test-repo"),
+					Dependabot:     tt.updates,
+					Files:          tt.files,
+				})
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			assert.ErrorContains(t, err, tt.expectedMsg)
+			assert.Empty(t, mocks.resources, "nothing should be registered for an invalid Dependabot configuration")
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const (
+	maxEnvironmentWaitTimer = 43200
+	maxEnvironmentReviewers = 6
+)
+
+// Environment defines a deployment environment of the repository.
+type Environment struct {
+	// The name of the environment, such as "staging" or "production".
+	Name string
+	// The time to wait before a deployment proceeds, in minutes (0-43200).
+	WaitTimer int
+	// The IDs of the users that must review deployments.
+	ReviewerUsers []int
+	// The IDs of the teams that must review deployments.
+	ReviewerTeams []int
+	// Prevent the user who triggered a deployment from approving it.
+	PreventSelfReview bool
+	// Allow repository administrators to bypass the protection rules. Defaults to true.
+	CanAdminsBypass *bool
+	// Restrict the branches and tags that can deploy. Nil allows all branches.
+	DeploymentBranchPolicy *DeploymentBranchPolicy
+	// The secrets of the environment, keyed by secret name. With
+	// EncryptSecrets, they are sealed against the environment's public key.
+	Secrets map[string]pulumi.StringInput
+	// The variables of the environment, keyed by variable name.
+	Variables map[string]pulumi.StringInput
+}
+
+// DeploymentBranchPolicy restricts the refs that can deploy to an environment.
+// Protected branches and custom patterns are mutually exclusive.
+type DeploymentBranchPolicy struct {
+	// Only branches with branch protection rules can deploy.
+	ProtectedBranches bool
+	// The branch name patterns that can deploy.
+	BranchPatterns []string
+	// The tag name patterns that can deploy.
+	TagPatterns []string
+}
+
+// validateEnvironments checks the environments and their secrets and variables.
+func validateEnvironments(environments []Environment) error {
+	names := make(map[string]string)
+	for _, env := range environments {
+		if env.Name == "" {
+			return fmt.Errorf("environment name must not be empty")
+		}
+		suffix := slug(env.Name)
+		if suffix == "" {
+			return fmt.Errorf("environment %q must contain at least one letter or digit", env.Name)
+		}
+		if other, ok := names[suffix]; ok {
+			return fmt.Errorf("environments %q and %q map to the same resource name", other, env.Name)
+		}
+		names[suffix] = env.Name
+
+		if env.WaitTimer < 0 || env.WaitTimer > maxEnvironmentWaitTimer {
+			return fmt.Errorf("environment %q: wait timer must be between 0 and %d minutes, got %d", env.Name, maxEnvironmentWaitTimer, env.WaitTimer)
+		}
+		if n := len(env.ReviewerUsers) + len(env.ReviewerTeams); n > maxEnvironmentReviewers {
+			return fmt.Errorf("environment %q: at most %d reviewers are allowed, got %d", env.Name, maxEnvironmentReviewers, n)
+		}
+		if policy := env.DeploymentBranchPolicy; policy != nil {
+			if err := validateDeploymentBranchPolicy(policy); err != nil {
+				return fmt.Errorf("environment %q: %w", env.Name, err)
+			}
+		}
+		if err := validateSecrets(env.Secrets); err != nil {
+			return fmt.Errorf("environment %q: %w", env.Name, err)
+		}
+		if err := validateVariables(env.Variables, env.Secrets); err != nil {
+			return fmt.Errorf("environment %q: %w", env.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateDeploymentBranchPolicy checks that the policy selects either protected
+// branches or custom patterns, and that the patterns map to distinct resources.
+func validateDeploymentBranchPolicy(policy *DeploymentBranchPolicy) error {
+	custom := len(policy.BranchPatterns) > 0 || len(policy.TagPatterns) > 0
+	if policy.ProtectedBranches && custom {
+		return fmt.Errorf("deployment branch policy cannot combine protected branches with custom patterns")
+	}
+	if !policy.ProtectedBranches && !custom {
+		return fmt.Errorf("deployment branch policy must allow protected branches or list at least one pattern")
+	}
+	for _, refs := range []struct {
+		kind     string
+		patterns []string
+	}{{"branch", policy.BranchPatterns}, {"tag", policy.TagPatterns}} {
+		kind := refs.kind
+		suffixes := make(map[string]string)
+		for _, pattern := range refs.patterns {
+			suffix := childSuffix(pattern)
+			if other, ok := suffixes[suffix]; ok {
+				return fmt.Errorf("%s patterns %q and %q map to the same resource name", kind, other, pattern)
+			}
+			suffixes[suffix] = pattern
+		}
+	}
+	return nil
+}
+
+// environmentPublicKey returns the Actions public key of an environment.
+type environmentPublicKey func(environment pulumi.StringOutput) pulumi.StringInput
+
+// lookupEnvironmentPublicKey reads the Actions public key of the environment
+// from the REST API, as the provider has no data source for it.
+func lookupEnvironmentPublicKey(ctx *pulumi.Context, repositoryFullName, environment pulumi.StringOutput, opts ...pulumi.InvokeOption) pulumi.StringOutput {
+	endpoint := pulumi.All(repositoryFullName, environment).ApplyT(func(args []any) string {
+		return "repos/" + args[0].(string) + "/environments/" + url.PathEscape(args[1].(string)) + "/secrets/public-key"
+	}).(pulumi.StringOutput)
+	response := github.GetRestApiOutput(ctx, github.GetRestApiOutputArgs{Endpoint: endpoint}, opts...)
+	return response.ApplyT(func(response github.GetRestApiResult) (string, error) {
+		var publicKey struct {
+			Key string `json:"key"`
+		}
+		if response.Code != http.StatusOK {
+			return "", fmt.Errorf("reading the public key of environment %s: %s", response.Endpoint, response.Status)
+		}
+		if err := json.Unmarshal([]byte(response.Body), &publicKey); err != nil {
+			return "", fmt.Errorf("reading the public key of environment %s: %w", response.Endpoint, err)
+		}
+		return publicKey.Key, nil
+	}).(pulumi.StringOutput)
+}
+
+// newEnvironments creates the deployment environments with their deployment
+// policies, secrets and variables, and returns the environments keyed by name.
+// When a public key lookup is given, the secrets are sealed against the key of
+// their environment client-side.
+func newEnvironments(ctx *pulumi.Context, name string, repository pulumi.StringInput, environments []Environment, publicKey environmentPublicKey, opts ...pulumi.ResourceOption) (map[string]*github.RepositoryEnvironment, error) {
+	created := make(map[string]*github.RepositoryEnvironment)
+	for _, env := range environments {
+		envName := childName(name, "environment-"+slug(env.Name))
+
+		canAdminsBypass := true
+		if env.CanAdminsBypass != nil {
+			canAdminsBypass = *env.CanAdminsBypass
+		}
+		envArgs := &github.RepositoryEnvironmentArgs{
+			Repository:        repository,
+			Environment:       pulumi.String(env.Name),
+			WaitTimer:         pulumi.Int(env.WaitTimer),
+			PreventSelfReview: pulumi.Bool(env.PreventSelfReview),
+			CanAdminsBypass:   pulumi.Bool(canAdminsBypass),
+		}
+		if len(env.ReviewerUsers) > 0 || len(env.ReviewerTeams) > 0 {
+			envArgs.Reviewers = github.RepositoryEnvironmentReviewerArray{
+				&github.RepositoryEnvironmentReviewerArgs{
+					Users: pulumi.ToIntArray(env.ReviewerUsers),
+					Teams: pulumi.ToIntArray(env.ReviewerTeams),
+				},
+			}
+		}
+		if policy := env.DeploymentBranchPolicy; policy != nil {
+			envArgs.DeploymentBranchPolicy = &github.RepositoryEnvironmentDeploymentBranchPolicyArgs{
+				ProtectedBranches:    pulumi.Bool(policy.ProtectedBranches),
+				CustomBranchPolicies: pulumi.Bool(!policy.ProtectedBranches),
+			}
+		}
+		environment, err := github.NewRepositoryEnvironment(ctx, envName, envArgs, opts...)
+		if err != nil {
+			return nil, err
+		}
+		created[env.Name] = environment
+
+		if policy := env.DeploymentBranchPolicy; policy != nil {
+			for _, pattern := range policy.BranchPatterns {
+				_, err := github.NewRepositoryEnvironmentDeploymentPolicy(ctx, envName+"-branch-"+childSuffix(pattern), &github.RepositoryEnvironmentDeploymentPolicyArgs{
+					Repository:    repository,
+					Environment:   environment.Environment,
+					BranchPattern: pulumi.String(pattern),
+				}, opts...)
+				if err != nil {
+					return nil, err
+				}
+			}
+			for _, pattern := range policy.TagPatterns {
+				_, err := github.NewRepositoryEnvironmentDeploymentPolicy(ctx, envName+"-tag-"+childSuffix(pattern), &github.RepositoryEnvironmentDeploymentPolicyArgs{
+					Repository:  repository,
+					Environment: environment.Environment,
+					TagPattern:  pulumi.String(pattern),
+				}, opts...)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		var envPublicKey pulumi.StringInput
+		if publicKey != nil && len(env.Secrets) > 0 {
+			envPublicKey = publicKey(environment.Environment)
+		}
+		for _, secretName := range slices.Sorted(maps.Keys(env.Secrets)) {
+			secretArgs := &github.ActionsEnvironmentSecretArgs{
+				Repository:  repository,
+				Environment: environment.Environment,
+				SecretName:  pulumi.String(secretName),
+			}
+			if envPublicKey != nil {
+				secretArgs.EncryptedValue = sealedValue(envPublicKey, env.Secrets[secretName], false)
+			} else {
+				secretArgs.PlaintextValue = pulumi.ToSecret(env.Secrets[secretName]).(pulumi.StringOutput)
+			}
+			_, err := github.NewActionsEnvironmentSecret(ctx, envName+"-secret-"+actionsSuffix(secretName), secretArgs, opts...)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, variableName := range slices.Sorted(maps.Keys(env.Variables)) {
+			_, err := github.NewActionsEnvironmentVariable(ctx, envName+"-variable-"+actionsSuffix(variableName), &github.ActionsEnvironmentVariableArgs{
+				Repository:   repository,
+				Environment:  environment.Environment,
+				VariableName: pulumi.String(variableName),
+				Value:        env.Variables[variableName],
+			}, opts...)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return created, nil
+}
@@ -0,0 +1,135 @@
+package github_test
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/softwaredevelop/pulumi-go-components/components/github"
+)
+
+func TestNewStandardRepo_Environments(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		repo, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			Environments: []github.Environment{
+				{
+					Name:                   "staging",
+					DeploymentBranchPolicy: &github.DeploymentBranchPolicy{ProtectedBranches: true},
+					Variables:              map[string]pulumi.StringInput{"API_URL": pulumi.String("https://staging.example.com")},
+				},
+				{
+					Name:              "production",
+					WaitTimer:         30,
+					ReviewerTeams:     []int{42},
+					PreventSelfReview: true,
+					DeploymentBranchPolicy: &github.DeploymentBranchPolicy{
+						BranchPatterns: []string{"main", "release/*", "release/**"},
+						TagPatterns:    []string{"v*"},
+					},
+					Secrets: map[string]pulumi.StringInput{"DEPLOY_TOKEN": pulumi.String("token")},
+				},
+			},
+		})
+		assert.NoError(t, err)
+		if assert.Len(t, repo.Environments, 2) {
+			assertOutputEquals(t, repo.Environments["production"].Environment, "production")
+		}
+		return nil
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"repo-environment-production", "repo-environment-staging"},
+		mocks.names("github:index/repositoryEnvironment:RepositoryEnvironment"))
+	assert.Equal(t, []string{
+		"repo-environment-production-branch-main",
+		"repo-environment-production-branch-release-4e7490f0",
+		"repo-environment-production-branch-release-5d122911",
+		"repo-environment-production-tag-v-af7e9fa4",
+	}, mocks.names("github:index/repositoryEnvironmentDeploymentPolicy:RepositoryEnvironmentDeploymentPolicy"))
+	assert.Equal(t, []string{"repo-environment-production-secret-deploy-token"},
+		mocks.names("github:index/actionsEnvironmentSecret:ActionsEnvironmentSecret"))
+	assert.Equal(t, []string{"repo-environment-staging-variable-api-url"},
+		mocks.names("github:index/actionsEnvironmentVariable:ActionsEnvironmentVariable"))
+
+	production := mocks.inputs("repo-environment-production")
+	assert.Equal(t, 30.0, production["waitTimer"].NumberValue())
+	assert.True(t, production["preventSelfReview"].BoolValue())
+	assert.True(t, production["deploymentBranchPolicy"].ObjectValue()["customBranchPolicies"].BoolValue())
+	assert.Len(t, production["reviewers"].ArrayValue()[0].ObjectValue()["teams"].ArrayValue(), 1)
+
+	secret := mocks.inputs("repo-environment-production-secret-deploy-token")
+	assert.True(t, secret["plaintextValue"].IsSecret(), "environment secrets must stay Pulumi secrets")
+}
+
+func TestNewStandardRepo_EncryptEnvironmentSecrets(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			Environments: []github.Environment{{
+				Name:    "production",
+				Secrets: map[string]pulumi.StringInput{"DEPLOY_TOKEN": pulumi.String("deploy-token-value")},
+			}},
+			EncryptSecrets: true,
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	inputs := mocks.inputs("repo-environment-production-secret-deploy-token")
+	assert.NotContains(t, inputs, resource.PropertyKey("plaintextValue"), "the plaintext value must not reach the provider")
+	encrypted := inputs["encryptedValue"]
+	assert.True(t, encrypted.IsSecret(), "the encrypted value must stay a Pulumi secret")
+	assert.NotContains(t, encrypted.SecretValue().Element.StringValue(), "deploy-token-value")
+}
+
+func TestNewStandardRepo_InvalidEnvironments(t *testing.T) {
+	tests := []struct {
+		name         string
+		environments []github.Environment
+		expectedMsg  string
+	}{
+		{"EmptyName", []github.Environment{{}}, "environment name must not be empty"},
+		{"Duplicate", []github.Environment{{Name: "prod"}, {Name: "Prod"}}, `environments "prod" and "Prod" map to the same resource name`},
+		{"WaitTimer", []github.Environment{{Name: "prod", WaitTimer: 50000}}, `environment "prod": wait timer must be between 0 and 43200 minutes`},
+		{"TooManyReviewers", []github.Environment{{Name: "prod", ReviewerUsers: []int{1, 2, 3, 4}, ReviewerTeams: []int{5, 6, 7}}}, "at most 6 reviewers are allowed, got 7"},
+		{
+			"ProtectedAndCustom",
+			[]github.Environment{{Name: "prod", DeploymentBranchPolicy: &github.DeploymentBranchPolicy{ProtectedBranches: true, BranchPatterns: []string{"main"}}}},
+			"cannot combine protected branches with custom patterns",
+		},
+		{
+			"EmptyPolicy",
+			[]github.Environment{{Name: "prod", DeploymentBranchPolicy: &github.DeploymentBranchPolicy{}}},
+			"must allow protected branches or list at least one pattern",
+		},
+		{
+			"VariableSharesSecretName",
+			[]github.Environment{{
+				Name:      "prod",
+				Secrets:   map[string]pulumi.StringInput{"TOKEN": pulumi.String("x")},
+				Variables: map[string]pulumi.StringInput{"TOKEN": pulumi.String("y")},
+			}},
+			`environment "prod": variable "TOKEN" has the same name as a secret`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName: pulumi.String("test-repo"),
+					Environments:   tt.environments,
+				})
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			assert.ErrorContains(t, err, tt.expectedMsg)
+			assert.Empty(t, mocks.resources, "nothing should be registered for invalid environments")
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package github
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// templates holds the built-in file templates. They mirror the .editorconfig
+// and the workflows of this repository.
+//
+//go:embed templates/editorconfig templates/workflows/*.yml
+var templates embed.FS
+
+// File defines a file committed to the default branch of the repository,
+// such as a CODEOWNERS, a LICENSE or a workflow.
+type File struct {
+	// The path of the file within the repository, such as ".github/CODEOWNERS".
+	Path string
+	// The content of the file.
+	Content pulumi.StringInput
+	// The commit message. Defaults to the provider's "Add <path>" message.
+	CommitMessage string
+}
+
+// CommitAuthor is the author of the commits that manage files.
+type CommitAuthor struct {
+	// The name of the author.
+	Name string
+	// The email address of the author.
+	Email string
+}
+
+// EditorConfigFile returns the .editorconfig of this repository.
+func EditorConfigFile() File {
+	return templateFile(".editorconfig", "templates/editorconfig")
+}
+
+// ActionlintWorkflowFile returns the workflow that lints the GitHub Actions workflows.
+func ActionlintWorkflowFile() File {
+	return templateFile(".github/workflows/actionlint.yml", "templates/workflows/actionlint.yml")
+}
+
+// EditorconfigCheckerWorkflowFile returns the workflow that checks files against the .editorconfig.
+func EditorconfigCheckerWorkflowFile() File {
+	return templateFile(".github/workflows/editorconfig-checker.yml", "templates/workflows/editorconfig-checker.yml")
+}
+
+// ReviveWorkflowFile returns the workflow that lints Go code with revive.
+func ReviveWorkflowFile() File {
+	return templateFile(".github/workflows/revive.yml", "templates/workflows/revive.yml")
+}
+
+// StandardFiles returns the .editorconfig and the workflows of this repository.
+func StandardFiles() []File {
+	return []File{
+		EditorConfigFile(),
+		ActionlintWorkflowFile(),
+		EditorconfigCheckerWorkflowFile(),
+		ReviveWorkflowFile(),
+	}
+}
+
+// CodeownersFile returns a .github/CODEOWNERS file that assigns every file of
+// the repository to the given owners, such as "@org/team" or "@user".
+func CodeownersFile(owners ...string) File {
+	return File{
+		Path:    ".github/CODEOWNERS",
+		Content: pulumi.String("* " + strings.Join(owners, " ") + "\n"),
+	}
+}
+
+// templateFile reads a built-in template. The templates are embedded, so a
+// missing template is a programming error.
+func templateFile(filePath, template string) File {
+	content, err := templates.ReadFile(template)
+	if err != nil {
+		panic(err)
+	}
+	return File{Path: filePath, Content: pulumi.String(string(content))}
+}
+
+// validateFiles checks the paths and contents of the files and the commit author.
+func validateFiles(files []File, author *CommitAuthor) error {
+	if author != nil && (author.Name == "" || author.Email == "") {
+		return fmt.Errorf("commit author must have both a name and an email")
+	}
+
+	suffixes := make(map[string]string)
+	for _, file := range files {
+		if file.Path == "" {
+			return fmt.Errorf("file path must not be empty")
+		}
+		if strings.HasPrefix(file.Path, "/") || path.Clean(file.Path) != file.Path || file.Path == ".." || strings.HasPrefix(file.Path, "../") {
+			return fmt.Errorf("file path %q must be a clean path relative to the repository root", file.Path)
+		}
+		if file.Content == nil {
+			return fmt.Errorf("file %q has no content", file.Path)
+		}
+		suffix := slug(file.Path)
+		if other, ok := suffixes[suffix]; ok {
+			return fmt.Errorf("files %q and %q map to the same resource name", other, file.Path)
+		}
+		suffixes[suffix] = file.Path
+	}
+	return nil
+}
+
+// validateFileCommits checks that the files can be committed to the default
+// branch, which fails when the ruleset only lets pull requests change it.
+func validateFileCommits(files []File, mode ProtectionMode, ruleset *RulesetArgs, branch string) error {
+	if len(files) > 0 && blocksDirectPushes(mode, ruleset, branch) {
+		return fmt.Errorf("files cannot be committed to %q, as the ruleset requires pull requests; "+
+			"add a bypass actor with bypass mode \"always\" for the committer", branch)
+	}
+	return nil
+}
+
+// newFiles commits the files to the given branch of the repository.
+func newFiles(ctx *pulumi.Context, name string, repository pulumi.StringInput, branch pulumi.StringInput, files []File, author *CommitAuthor, overwriteOnCreate bool, opts ...pulumi.ResourceOption) error {
+	for _, file := range files {
+		fileArgs := &github.RepositoryFileArgs{
+			Repository:        repository,
+			Branch:            branch,
+			File:              pulumi.String(file.Path),
+			Content:           file.Content,
+			OverwriteOnCreate: pulumi.Bool(overwriteOnCreate),
+		}
+		if file.CommitMessage != "" {
+			fileArgs.CommitMessage = pulumi.String(file.CommitMessage)
+		}
+		if author != nil {
+			fileArgs.CommitAuthor = pulumi.String(author.Name)
+			fileArgs.CommitEmail = pulumi.String(author.Email)
+		}
+		_, err := github.NewRepositoryFile(ctx, childName(name, "file-"+slug(file.Path)), fileArgs, opts...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package github_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/softwaredevelop/pulumi-go-components/components/github"
+)
+
+func TestStandardFiles_MirrorRepository(t *testing.T) {
+	// The built-in templates must stay in sync with this repository's own files.
+	for _, file := range github.StandardFiles() {
+		t.Run(file.Path, func(t *testing.T) {
+			expected, err := os.ReadFile(filepath.Join("..", "..", filepath.FromSlash(file.Path)))
+			require.NoError(t, err)
+			assertOutputEquals(t, file.Content.ToStringOutput(), string(expected))
+		})
+	}
+}
+
+func TestNewStandardRepo_Files(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName:         pulumi.String("test-repo"),
+			Files:                  append(github.StandardFiles(), github.CodeownersFile("@org/platform", "@octocat")),
+			FileCommitAuthor:       &github.CommitAuthor{Name: "Platform Bot", Email: "platform-bot@example.com"},
+			OverwriteFilesOnCreate: true,
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"repo-file-editorconfig",
+		"repo-file-github-codeowners",
+		"repo-file-github-workflows-actionlint-yml",
+		"repo-file-github-workflows-editorconfig-checker-yml",
+		"repo-file-github-workflows-revive-yml",
+	}, mocks.names("github:index/repositoryFile:RepositoryFile"))
+
+	codeowners := mocks.inputs("repo-file-github-codeowners")
+	assert.Equal(t, ".github/CODEOWNERS", codeowners["file"].StringValue())
+	assert.Equal(t, "* @org/platform @octocat\n", codeowners["content"].StringValue())
+	assert.Equal(t, "main", codeowners["branch"].StringValue())
+	assert.Equal(t, "Platform Bot", codeowners["commitAuthor"].StringValue())
+	assert.Equal(t, "platform-bot@example.com", codeowners["commitEmail"].StringValue())
+	assert.True(t, codeowners["overwriteOnCreate"].BoolValue())
+}
+
+func TestNewStandardRepo_InvalidFiles(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       []github.File
+		author      *github.CommitAuthor
+		expectedMsg string
+	}{
+		{"EmptyPath", []github.File{{Content: pulumi.String("x")}}, nil, "file path must not be empty"},
+		{"AbsolutePath", []github.File{{Path: "/LICENSE", Content: pulumi.String("x")}}, nil, `file path "/LICENSE" must be a clean path relative to the repository root`},
+		{"ParentPath", []github.File{{Path: "../LICENSE", Content: pulumi.String("x")}}, nil, `file path "../LICENSE" must be a clean path`},
+		{"UncleanPath", []github.File{{Path: "docs/../LICENSE", Content: pulumi.String("x")}}, nil, `file path "docs/../LICENSE" must be a clean path`},
+		{"NoContent", []github.File{{Path: "LICENSE"}}, nil, `file "LICENSE" has no content`},
+		{"Duplicate", []github.File{github.EditorConfigFile(), github.EditorConfigFile()}, nil, `files ".editorconfig" and ".editorconfig" map to the same resource name`},
+		{"AuthorWithoutEmail", nil, &github.CommitAuthor{Name: "Platform Bot"}, "commit author must have both a name and an email"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName:   pulumi.String("test-repo"),
+					Files:            tt.files,
+					FileCommitAuthor: tt.author,
+				})
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			assert.ErrorContains(t, err, tt.expectedMsg)
+			assert.Empty(t, mocks.resources, "nothing should be registered for invalid files")
+		})
+	}
+}
+
+func TestNewStandardRepo_FilesUnderPullRequestRuleset(t *testing.T) {
+	reviews := &github.RequiredReviews{ApprovingReviewCount: 1}
+	tests := []struct {
+		name        string
+		ruleset     *github.RulesetArgs
+		dependabot  []github.DependabotUpdate
+		expectedMsg string
+	}{
+		{"RequiredReviews", &github.RulesetArgs{RequiredReviews: reviews}, nil, `files cannot be committed to "main", as the ruleset requires pull requests`},
+		{"Dependabot", &github.RulesetArgs{RequiredReviews: reviews}, []github.DependabotUpdate{{Ecosystem: "gomod"}}, `files cannot be committed to "main"`},
+		{"PullRequestBypass", &github.RulesetArgs{RequiredReviews: reviews, BypassActors: []github.BypassActor{
+			{ActorID: 5, ActorType: "RepositoryRole", BypassMode: "pull_request"},
+		}}, nil, `files cannot be committed to "main"`},
+		{"AlwaysBypass", &github.RulesetArgs{RequiredReviews: reviews, BypassActors: []github.BypassActor{
+			{ActorID: 5, ActorType: "RepositoryRole"},
+		}}, nil, ""},
+		{"Evaluate", &github.RulesetArgs{RequiredReviews: reviews, Enforcement: "evaluate"}, nil, ""},
+		{"OtherBranches", &github.RulesetArgs{RequiredReviews: reviews, Include: []string{"refs/heads/release/*"}}, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var files []github.File
+			if tt.dependabot == nil {
+				files = []github.File{github.EditorConfigFile()}
+			}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName: pulumi.String("test-repo"),
+					ProtectionMode: github.ProtectionRuleset,
+					Ruleset:        tt.ruleset,
+					Files:          files,
+					Dependabot:     tt.dependabot,
+				})
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", standardRepoMocks(0)))
+			if tt.expectedMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expectedMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package github
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// Label defines an issue label of the repository.
+type Label struct {
+	// The name of the label, at most 50 characters.
+	Name string `pulumi:"name"`
+	// The color of the label as a 6 digit hex code, without the leading '#'.
+	Color string `pulumi:"color"`
+	// A short description of the label, at most 100 characters.
+	Description string `pulumi:"description"`
+}
+
+// LabelCatalog names a built-in set of labels.
+type LabelCatalog string
+
+const (
+	// LabelCatalogDependabot holds the labels used by Dependabot pull requests.
+	LabelCatalogDependabot LabelCatalog = "dependabot"
+	// LabelCatalogTriage holds the labels used to triage issues.
+	LabelCatalogTriage LabelCatalog = "triage"
+	// LabelCatalogRelease holds the labels used to group changes in release notes.
+	LabelCatalogRelease LabelCatalog = "release"
+)
+
+const (
+	maxLabelNameLength        = 50
+	maxLabelDescriptionLength = 100
+)
+
+// githubActionsLabel is the label created when no labels are declared.
+var githubActionsLabel = Label{
+	Name:        "github-actions dependencies",
+	Color:       "E66E01",
+	Description: "This issue is related to github-actions dependencies",
+}
+
+// labelCatalogs holds the labels of the built-in catalogs.
+var labelCatalogs = map[LabelCatalog][]Label{
+	LabelCatalogDependabot: {
+		{Name: "dependencies", Color: "0366D6", Description: "Pull requests that update a dependency file"},
+		githubActionsLabel,
+		{Name: "go-modules dependencies", Color: "9BE688", Description: "This issue is related to go modules dependencies"},
+	},
+	LabelCatalogTriage: {
+		{Name: "bug", Color: "D73A4A", Description: "Something isn't working"},
+		{Name: "enhancement", Color: "A2EEEF", Description: "New feature or request"},
+		{Name: "question", Color: "D876E3", Description: "Further information is requested"},
+		{Name: "duplicate", Color: "CFD3D7", Description: "This issue or pull request already exists"},
+		{Name: "needs triage", Color: "FBCA04", Description: "This issue has not been triaged yet"},
+		{Name: "wontfix", Color: "FFFFFF", Description: "This will not be worked on"},
+	},
+	LabelCatalogRelease: {
+		{Name: "breaking change", Color: "B60205", Description: "This change breaks backwards compatibility"},
+		{Name: "feature", Color: "1D76DB", Description: "This change adds a new feature"},
+		{Name: "fix", Color: "0E8A16", Description: "This change fixes a bug"},
+		{Name: "skip changelog", Color: "EDEDED", Description: "This change is left out of the release notes"},
+	},
+}
+
+// legacyLabelSuffixes keeps the child name suffix that labels had before they
+// were derived from the label name, so that existing stacks are not replaced.
+var legacyLabelSuffixes = map[string]string{
+	githubActionsLabel.Name: "gh-actions",
+}
+
+var labelColorPattern = regexp.MustCompile(`^[0-9A-Fa-f]{6}$`)
+
+// resolveLabels expands the catalogs and merges them with the explicitly
+// declared labels, which take precedence over catalog labels of the same name.
+// Without any catalog or label, only the github-actions label is created.
+func resolveLabels(catalogs []LabelCatalog, labels []Label) ([]Label, error) {
+	if len(catalogs) == 0 && len(labels) == 0 {
+		return []Label{githubActionsLabel}, nil
+	}
+
+	var resolved []Label
+	index := make(map[string]int)
+	for _, catalog := range catalogs {
+		catalogLabels, ok := labelCatalogs[catalog]
+		if !ok {
+			return nil, fmt.Errorf("unknown label catalog %q", catalog)
+		}
+		for _, label := range catalogLabels {
+			key := strings.ToLower(label.Name)
+			if _, ok := index[key]; !ok {
+				index[key] = len(resolved)
+				resolved = append(resolved, label)
+			}
+		}
+	}
+
+	declared := make(map[string]bool)
+	for _, label := range labels {
+		key := strings.ToLower(label.Name)
+		if declared[key] {
+			return nil, fmt.Errorf("label %q is declared more than once", label.Name)
+		}
+		declared[key] = true
+		if i, ok := index[key]; ok {
+			resolved[i] = label
+			continue
+		}
+		index[key] = len(resolved)
+		resolved = append(resolved, label)
+	}
+	return resolved, nil
+}
+
+// validateLabels checks the names, colors and descriptions of the labels, and
+// that no two labels map to the same child resource name.
+func validateLabels(labels []Label) error {
+	suffixes := make(map[string]string)
+	for _, label := range labels {
+		if label.Name == "" {
+			return fmt.Errorf("label name must not be empty")
+		}
+		if n := utf8.RuneCountInString(label.Name); n > maxLabelNameLength {
+			return fmt.Errorf("label %q is %d characters long, the maximum is %d", label.Name, n, maxLabelNameLength)
+		}
+		if !labelColorPattern.MatchString(label.Color) {
+			return fmt.Errorf("label %q has invalid color %q, expected a 6 digit hex code without '#'", label.Name, label.Color)
+		}
+		if n := utf8.RuneCountInString(label.Description); n > maxLabelDescriptionLength {
+			return fmt.Errorf("label %q has a description of %d characters, the maximum is %d", label.Name, n, maxLabelDescriptionLength)
+		}
+		suffix := labelSuffix(label.Name)
+		if other, ok := suffixes[suffix]; ok {
+			return fmt.Errorf("labels %q and %q map to the same resource name", other, label.Name)
+		}
+		suffixes[suffix] = label.Name
+	}
+	return nil
+}
+
+// newLabels creates the issue labels of the repository and returns them keyed
+// by label name, together with the github.IssueLabels resource of
+// authoritative mode. In that mode it manages all labels, removing any label
+// that is not declared. The labels remain individual resources as well, which
+// are retained on delete: a stack that switches to authoritative mode keeps
+// them instead of deleting the labels that IssueLabels then manages, and the
+// removal of a label is left to IssueLabels. The IssueLabels resource is
+// retained on delete too, so that switching back keeps the labels. A non-nil
+// adoption imports the existing labels.
+func newLabels(ctx *pulumi.Context, name string, repository pulumi.StringInput, labels []Label, authoritative, legacyNames bool, adopt *adoption, opts ...pulumi.ResourceOption) (map[string]*github.IssueLabel, *github.IssueLabels, error) {
+	labelOpts := slices.Clone(opts)
+	var issueLabels *github.IssueLabels
+	if authoritative {
+		labelArgs := github.IssueLabelsLabelArray{}
+		for _, label := range labels {
+			labelArgs = append(labelArgs, &github.IssueLabelsLabelArgs{
+				Name:        pulumi.String(label.Name),
+				Color:       pulumi.String(label.Color),
+				Description: pulumi.String(label.Description),
+			})
+		}
+		labelsOpts := append(slices.Clone(opts), pulumi.RetainOnDelete(true))
+		if adopt != nil {
+			labelsOpts = append(labelsOpts, adopt.importRepository())
+		}
+		var err error
+		issueLabels, err = github.NewIssueLabels(ctx, childName(name, "labels"), &github.IssueLabelsArgs{
+			Repository: repository,
+			Labels:     labelArgs,
+		}, labelsOpts...)
+		if err != nil {
+			return nil, nil, err
+		}
+		labelOpts = append(labelOpts, pulumi.RetainOnDelete(true))
+	}
+
+	created := make(map[string]*github.IssueLabel)
+	for _, label := range labels {
+		suffix := "label-" + labelSuffix(label.Name)
+		options := append(slices.Clone(labelOpts), legacyLabelAlias(legacyNames, label.Name, suffix))
+		if adopt != nil {
+			options = append(options, adopt.importLabel(label.Name))
+		}
+		issueLabel, err := github.NewIssueLabel(ctx, childName(name, suffix), &github.IssueLabelArgs{
+			Repository:  repository,
+			Name:        pulumi.String(label.Name),
+			Color:       pulumi.String(label.Color),
+			Description: pulumi.String(label.Description),
+		}, options...)
+		if err != nil {
+			return nil, nil, err
+		}
+		created[label.Name] = issueLabel
+	}
+	return created, issueLabels, nil
+}
+
+// legacyLabelAlias returns the alias of a label to its former fixed name,
+// which only the labels of legacyLabelSuffixes had.
+func legacyLabelAlias(enabled bool, labelName, suffix string) pulumi.ResourceOption {
+	_, ok := legacyLabelSuffixes[labelName]
+	return legacyAlias(enabled && ok, suffix)
+}
+
+// labelSuffix derives the child name suffix of a label from its name. The
+// slug keeps the ASCII letters and digits, so labels that differ in other
+// characters only are rejected by validateLabels. A name with letters or
+// digits outside ASCII, which the slug drops, gets a short hash of the name
+// appended instead, as does a name without any letter or digit.
+func labelSuffix(labelName string) string {
+	if suffix, ok := legacyLabelSuffixes[labelName]; ok {
+		return suffix
+	}
+	suffix := slug(labelName)
+	if suffix == "" || !isASCII(labelName) {
+		// Label names are case-insensitive.
+		return hashedSuffix(suffix, strings.ToLower(labelName))
+	}
+	return suffix
+}
+
+// hashedSuffix appends the first 8 hex digits of the SHA-256 hash of value to
+// the suffix, or returns them alone for an empty suffix.
+func hashedSuffix(suffix, value string) string {
+	sum := sha256.Sum256([]byte(value))
+	hash := hex.EncodeToString(sum[:4])
+	if suffix == "" {
+		return hash
+	}
+	return suffix + "-" + hash
+}
+
+// childSuffix returns the slug of value, with a hash of value appended when
+// the slug loses information, so that distinct values never share a suffix.
+func childSuffix(value string) string {
+	suffix := slug(value)
+	if suffix == value {
+		return suffix
+	}
+	return hashedSuffix(suffix, value)
+}
+
+// isASCII reports whether s only consists of ASCII characters.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// slug lowercases s and replaces every run of characters other than ASCII
+// letters and digits with a single '-'.
+func slug(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if ('a' <= r && r <= 'z') || ('0' <= r && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
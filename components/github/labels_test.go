@@ -0,0 +1,159 @@
+package github_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/softwaredevelop/pulumi-go-components/components/github"
+)
+
+func TestNewStandardRepo_Labels(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			LabelCatalogs:  []github.LabelCatalog{github.LabelCatalogDependabot},
+			Labels: []github.Label{
+				{Name: "go-modules dependencies", Color: "00ADD8", Description: "Go modules"},
+				{Name: "priority high", Color: "b60205"},
+			},
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"repo-label-dependencies",
+		"repo-label-gh-actions",
+		"repo-label-go-modules-dependencies",
+		"repo-label-priority-high",
+	}, mocks.names("github:index/issueLabel:IssueLabel"))
+	assert.Equal(t, "00ADD8", mocks.inputs("repo-label-go-modules-dependencies")["color"].StringValue(),
+		"declared labels should override catalog labels of the same name")
+}
+
+func TestNewStandardRepo_DefaultLabel(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"repo-label-gh-actions"}, mocks.names("github:index/issueLabel:IssueLabel"))
+	assert.Equal(t, "github-actions dependencies", mocks.inputs("repo-label-gh-actions")["name"].StringValue())
+}
+
+func TestNewStandardRepo_AuthoritativeLabels(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName:      pulumi.String("test-repo"),
+			LabelCatalogs:       []github.LabelCatalog{github.LabelCatalogTriage, github.LabelCatalogRelease},
+			AuthoritativeLabels: true,
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"repo-labels"}, mocks.names("github:index/issueLabels:IssueLabels"))
+	assert.Len(t, mocks.inputs("repo-labels")["labels"].ArrayValue(), 10)
+	assert.True(t, mocks.retainOnDelete("repo-labels"), "the labels should be kept when switching back")
+	labels := mocks.names("github:index/issueLabel:IssueLabel")
+	assert.Len(t, labels, 10)
+	for _, label := range labels {
+		assert.True(t, mocks.retainOnDelete(label), "%s should be retained, as IssueLabels manages the label", label)
+	}
+}
+
+func TestNewStandardRepo_AuthoritativeLabelsSwitch(t *testing.T) {
+	deploy := func(authoritative bool) *recordingMocks {
+		mocks := &recordingMocks{}
+		err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+			_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+				RepositoryName:      pulumi.String("test-repo"),
+				LabelCatalogs:       []github.LabelCatalog{github.LabelCatalogTriage},
+				AuthoritativeLabels: authoritative,
+			})
+			return err
+		}, pulumi.WithMocks("test-project", "test-stack", mocks))
+		assert.NoError(t, err)
+		return mocks
+	}
+	before, after := deploy(false), deploy(true)
+
+	// Every label of the stack is still declared after the switch, so none
+	// is deleted while IssueLabels takes over.
+	labels := before.names("github:index/issueLabel:IssueLabel")
+	assert.NotEmpty(t, labels)
+	assert.ElementsMatch(t, labels, after.names("github:index/issueLabel:IssueLabel"))
+	for _, label := range labels {
+		assert.False(t, before.retainOnDelete(label), "%s should be deleted with the stack before the switch", label)
+		assert.True(t, after.retainOnDelete(label), "%s should be retained after the switch", label)
+	}
+}
+
+func TestNewStandardRepo_NonASCIILabels(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			Labels: []github.Label{
+				{Name: "バグ", Color: "D73A4A"},
+				{Name: "バグ 2", Color: "D73A4A"},
+				{Name: "2", Color: "D73A4A"},
+				{Name: "🚀", Color: "1D76DB"},
+				{Name: "🐛", Color: "1D76DB"},
+			},
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	// Names whose letters the slug drops get a hash of the name instead.
+	assert.ElementsMatch(t, []string{
+		"repo-label-468944a1",
+		"repo-label-2-25982725",
+		"repo-label-2",
+		"repo-label-ebbc0b28",
+		"repo-label-e41b4d36",
+	}, mocks.names("github:index/issueLabel:IssueLabel"))
+}
+
+func TestNewStandardRepo_InvalidLabels(t *testing.T) {
+	tests := []struct {
+		name        string
+		catalogs    []github.LabelCatalog
+		labels      []github.Label
+		expectedMsg string
+	}{
+		{"UnknownCatalog", []github.LabelCatalog{"chores"}, nil, `unknown label catalog "chores"`},
+		{"EmptyName", nil, []github.Label{{Color: "FFFFFF"}}, "label name must not be empty"},
+		{"NameTooLong", nil, []github.Label{{Name: strings.Repeat("x", 51), Color: "FFFFFF"}}, "the maximum is 50"},
+		{"ColorWithHash", nil, []github.Label{{Name: "bug", Color: "#FFFFFF"}}, `label "bug" has invalid color "#FFFFFF"`},
+		{"ShortColor", nil, []github.Label{{Name: "bug", Color: "FFF"}}, `label "bug" has invalid color "FFF"`},
+		{"Duplicate", nil, []github.Label{{Name: "bug", Color: "FFFFFF"}, {Name: "Bug", Color: "000000"}}, `label "Bug" is declared more than once`},
+		{"SameResourceName", nil, []github.Label{{Name: "a b", Color: "FFFFFF"}, {Name: "a-b", Color: "000000"}}, `labels "a b" and "a-b" map to the same resource name`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName: pulumi.String("test-repo"),
+					LabelCatalogs:  tt.catalogs,
+					Labels:         tt.labels,
+				})
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			assert.ErrorContains(t, err, tt.expectedMsg)
+			assert.Empty(t, mocks.resources, "nothing should be registered for invalid labels")
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package github
+
+import "fmt"
+
+// Lifecycle decides what happens to the repository when the component is deleted,
+// for example by pulumi destroy.
+type Lifecycle string
+
+const (
+	// LifecycleDelete deletes the repository. It is the default lifecycle when none is given.
+	LifecycleDelete Lifecycle = "delete"
+	// LifecycleArchive archives the repository instead of deleting it.
+	LifecycleArchive Lifecycle = "archive"
+	// LifecycleProtect protects the repository, so that deleting it fails
+	// until the protection is lifted.
+	LifecycleProtect Lifecycle = "protect"
+)
+
+// validateLifecycle checks the lifecycle of the repository. Adopted
+// repositories cannot be archived on destroy, as GitHub does not report
+// archiveOnDestroy, so their import would always show a difference.
+func validateLifecycle(lifecycle Lifecycle, adopt bool) error {
+	switch lifecycle {
+	case "", LifecycleDelete, LifecycleProtect:
+		return nil
+	case LifecycleArchive:
+		if adopt {
+			return fmt.Errorf("lifecycle %q does not apply to adopted repositories; turn Adopt off once the import has completed", lifecycle)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown lifecycle %q", lifecycle)
+}
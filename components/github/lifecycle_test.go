@@ -0,0 +1,111 @@
+package github_test
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/softwaredevelop/pulumi-go-components/components/github"
+)
+
+// registration returns the protect and retain-on-delete options recorded for
+// the resource with the given logical name.
+func (m *recordingMocks) registration(name string) (protect, retainOnDelete bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range m.resources {
+		if r.Name == name && r.RegisterRPC != nil {
+			return r.RegisterRPC.GetProtect(), r.RegisterRPC.GetRetainOnDelete()
+		}
+	}
+	return false, false
+}
+
+func TestNewStandardRepo_Lifecycle(t *testing.T) {
+	tests := []struct {
+		name             string
+		lifecycle        github.Lifecycle
+		protect          bool
+		archiveOnDestroy bool
+	}{
+		{"Default", "", false, false},
+		{"Delete", github.LifecycleDelete, false, false},
+		{"Archive", github.LifecycleArchive, false, true},
+		{"Protect", github.LifecycleProtect, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName: pulumi.String("test-repo"),
+					Lifecycle:      tt.lifecycle,
+				})
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			assert.NoError(t, err)
+
+			protect, retainOnDelete := mocks.registration("repo-repository")
+			assert.Equal(t, tt.protect, protect)
+			assert.False(t, retainOnDelete)
+			if tt.archiveOnDestroy {
+				assert.True(t, mocks.inputs("repo-repository")["archiveOnDestroy"].BoolValue())
+			} else {
+				assert.NotContains(t, mocks.inputs("repo-repository"), "archiveOnDestroy")
+			}
+
+			// The lifecycle of the repository does not apply to the other children.
+			protect, _ = mocks.registration("repo-branch-protection")
+			assert.False(t, protect)
+		})
+	}
+}
+
+func TestNewStandardRepo_ChildOptions(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName:         pulumi.String("test-repo"),
+			Profile:                github.ProfileOpenSource,
+			AutoInit:               ptr(true),
+			Lifecycle:              github.LifecycleArchive,
+			ProtectChildren:        true,
+			RetainChildrenOnDelete: true,
+			Secrets:                map[string]pulumi.StringInput{"TOKEN": pulumi.String("token")},
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	for _, name := range []string{
+		"repo-default-branch",
+		"repo-branch-protection",
+		"repo-label-gh-actions",
+		"repo-secret-token",
+		"repo-dependabot-security-updates",
+	} {
+		protect, retainOnDelete := mocks.registration(name)
+		assert.True(t, protect, "%s should be protected", name)
+		assert.True(t, retainOnDelete, "%s should be retained on delete", name)
+	}
+
+	// The repository itself follows its lifecycle.
+	protect, retainOnDelete := mocks.registration("repo-repository")
+	assert.False(t, protect)
+	assert.False(t, retainOnDelete)
+}
+
+func TestNewStandardRepo_UnknownLifecycle(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			Lifecycle:      "retain",
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.ErrorContains(t, err, `unknown lifecycle "retain"`)
+	assert.Empty(t, mocks.resources)
+}
@@ -0,0 +1,115 @@
+package github
+
+import (
+	"fmt"
+	"slices"
+)
+
+// MergePolicy controls how pull requests are merged. Nil merge methods keep
+// the value of the profile and Policy, while the remaining nil or empty
+// fields keep GitHub's defaults. A merge method that stays unset is allowed,
+// as it is by default on GitHub.
+type MergePolicy struct {
+	// Allow merge commits on pull requests.
+	AllowMergeCommit *bool
+	// Allow squash merging on pull requests.
+	AllowSquashMerge *bool
+	// Allow rebase merging on pull requests.
+	AllowRebaseMerge *bool
+	// Allow pull requests to be merged automatically once their requirements are met.
+	AllowAutoMerge *bool
+	// Suggest updating pull request branches that are behind their base branch.
+	AllowUpdateBranch *bool
+	// The title of merge commits: "PR_TITLE" or "MERGE_MESSAGE".
+	// It must be set together with MergeCommitMessage.
+	MergeCommitTitle string
+	// The message of merge commits: "PR_BODY", "PR_TITLE" or "BLANK".
+	MergeCommitMessage string
+	// The title of squash merge commits: "PR_TITLE" or "COMMIT_OR_PR_TITLE".
+	// It must be set together with SquashMergeCommitMessage.
+	SquashMergeCommitTitle string
+	// The message of squash merge commits: "PR_BODY", "COMMIT_MESSAGES" or "BLANK".
+	SquashMergeCommitMessage string
+}
+
+// mergeCommitFormats holds the message formats GitHub accepts for each merge commit title.
+var mergeCommitFormats = map[string][]string{
+	"MERGE_MESSAGE": {"PR_TITLE"},
+	"PR_TITLE":      {"PR_BODY", "BLANK"},
+}
+
+// squashMergeCommitFormats holds the message formats GitHub accepts for each squash merge commit title.
+var squashMergeCommitFormats = map[string][]string{
+	"COMMIT_OR_PR_TITLE": {"COMMIT_MESSAGES"},
+	"PR_TITLE":           {"PR_BODY", "COMMIT_MESSAGES", "BLANK"},
+}
+
+// resolveMergePolicy applies the non-nil fields of the override on top of the
+// merge methods of the policy, and rejects contradictory combinations,
+// including merge commits as the only method when linear history is required.
+func resolveMergePolicy(policy RepositoryPolicy, override *MergePolicy, linearHistory bool) (MergePolicy, error) {
+	merge := MergePolicy{
+		AllowMergeCommit: policy.AllowMergeCommit,
+		AllowSquashMerge: policy.AllowSquashMerge,
+		AllowRebaseMerge: policy.AllowRebaseMerge,
+	}
+	if override != nil {
+		overrideField(&merge.AllowMergeCommit, override.AllowMergeCommit)
+		overrideField(&merge.AllowSquashMerge, override.AllowSquashMerge)
+		overrideField(&merge.AllowRebaseMerge, override.AllowRebaseMerge)
+		merge.AllowAutoMerge = override.AllowAutoMerge
+		merge.AllowUpdateBranch = override.AllowUpdateBranch
+		merge.MergeCommitTitle = override.MergeCommitTitle
+		merge.MergeCommitMessage = override.MergeCommitMessage
+		merge.SquashMergeCommitTitle = override.SquashMergeCommitTitle
+		merge.SquashMergeCommitMessage = override.SquashMergeCommitMessage
+	}
+
+	mergeCommit := allowed(merge.AllowMergeCommit)
+	squash := allowed(merge.AllowSquashMerge)
+	rebase := allowed(merge.AllowRebaseMerge)
+	if !mergeCommit && !squash && !rebase {
+		return MergePolicy{}, fmt.Errorf("at least one of merge commits, squash merging and rebase merging must be allowed")
+	}
+	if linearHistory && mergeCommit && !squash && !rebase {
+		return MergePolicy{}, fmt.Errorf("required linear history rejects merge commits, so squash or rebase merging must be allowed")
+	}
+	if err := validateCommitFormat("merge commit", merge.MergeCommitTitle, merge.MergeCommitMessage, mergeCommitFormats); err != nil {
+		return MergePolicy{}, err
+	}
+	if merge.MergeCommitTitle != "" && !mergeCommit {
+		return MergePolicy{}, fmt.Errorf("merge commit title and message require merge commits to be allowed")
+	}
+	if err := validateCommitFormat("squash merge commit", merge.SquashMergeCommitTitle, merge.SquashMergeCommitMessage, squashMergeCommitFormats); err != nil {
+		return MergePolicy{}, err
+	}
+	if merge.SquashMergeCommitTitle != "" && !squash {
+		return MergePolicy{}, fmt.Errorf("squash merge commit title and message require squash merging to be allowed")
+	}
+	return merge, nil
+}
+
+// validateCommitFormat checks that the title and message are set together and
+// form one of the combinations GitHub accepts.
+func validateCommitFormat(kind, title, message string, formats map[string][]string) error {
+	if title == "" && message == "" {
+		return nil
+	}
+	if title == "" || message == "" {
+		return fmt.Errorf("%s title and message must be set together", kind)
+	}
+	messages, ok := formats[title]
+	if !ok {
+		return fmt.Errorf("unknown %s title %q", kind, title)
+	}
+	if !slices.Contains(messages, message) {
+		return fmt.Errorf("%s title %q cannot be combined with message %q", kind, title, message)
+	}
+	return nil
+}
+
+// allowed reports whether a merge method is allowed. GitHub allows every
+// merge method by default, so an unset method is allowed.
+func allowed(method *bool) bool {
+	return method == nil || *method
+}
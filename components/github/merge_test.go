@@ -0,0 +1,163 @@
+package github_test
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/softwaredevelop/pulumi-go-components/components/github"
+)
+
+func TestNewStandardRepo_MergePolicy(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			Profile:        github.ProfilePrivateConfidential,
+			MergePolicy: &github.MergePolicy{
+				AllowRebaseMerge:         ptr(true),
+				AllowAutoMerge:           ptr(true),
+				AllowUpdateBranch:        ptr(true),
+				SquashMergeCommitTitle:   "PR_TITLE",
+				SquashMergeCommitMessage: "PR_BODY",
+			},
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	repository := mocks.inputs("repo-repository")
+	// Merge methods that are not overridden keep the value of the profile.
+	assert.False(t, repository["allowMergeCommit"].BoolValue())
+	assert.True(t, repository["allowSquashMerge"].BoolValue())
+	assert.True(t, repository["allowRebaseMerge"].BoolValue())
+	assert.True(t, repository["allowAutoMerge"].BoolValue())
+	assert.True(t, repository["allowUpdateBranch"].BoolValue())
+	assert.Equal(t, "PR_TITLE", repository["squashMergeCommitTitle"].StringValue())
+	assert.Equal(t, "PR_BODY", repository["squashMergeCommitMessage"].StringValue())
+	assert.NotContains(t, repository, "mergeCommitTitle")
+	assert.NotContains(t, repository, "mergeCommitMessage")
+}
+
+func TestNewStandardRepo_MergeCommitsWithoutLinearHistory(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			ProtectionMode: github.ProtectionRuleset,
+			Ruleset:        &github.RulesetArgs{RequiredLinearHistory: ptr(false)},
+			MergePolicy: &github.MergePolicy{
+				AllowMergeCommit:   ptr(true),
+				AllowSquashMerge:   ptr(false),
+				AllowRebaseMerge:   ptr(false),
+				MergeCommitTitle:   "PR_TITLE",
+				MergeCommitMessage: "BLANK",
+			},
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	repository := mocks.inputs("repo-repository")
+	assert.True(t, repository["allowMergeCommit"].BoolValue())
+	assert.Equal(t, "PR_TITLE", repository["mergeCommitTitle"].StringValue())
+	assert.Equal(t, "BLANK", repository["mergeCommitMessage"].StringValue())
+}
+
+func TestNewStandardRepo_UnsetMergeMethods(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			// Unset merge methods keep GitHub's default and are allowed.
+			MergePolicy: &github.MergePolicy{
+				AllowRebaseMerge:         ptr(false),
+				MergeCommitTitle:         "PR_TITLE",
+				MergeCommitMessage:       "PR_BODY",
+				SquashMergeCommitTitle:   "PR_TITLE",
+				SquashMergeCommitMessage: "BLANK",
+			},
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	repository := mocks.inputs("repo-repository")
+	assert.NotContains(t, repository, "allowMergeCommit")
+	assert.NotContains(t, repository, "allowSquashMerge")
+	assert.False(t, repository["allowRebaseMerge"].BoolValue())
+	assert.Equal(t, "PR_BODY", repository["mergeCommitMessage"].StringValue())
+	assert.Equal(t, "BLANK", repository["squashMergeCommitMessage"].StringValue())
+}
+
+func TestNewStandardRepo_InvalidMergePolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        github.ProtectionMode
+		ruleset     *github.RulesetArgs
+		merge       *github.MergePolicy
+		expectedMsg string
+	}{
+		{
+			name:        "NoMergeMethod",
+			merge:       &github.MergePolicy{AllowMergeCommit: ptr(false), AllowSquashMerge: ptr(false), AllowRebaseMerge: ptr(false)},
+			expectedMsg: "at least one of merge commits, squash merging and rebase merging must be allowed",
+		},
+		{
+			name:        "MergeCommitsOnlyWithBranchProtection",
+			merge:       &github.MergePolicy{AllowMergeCommit: ptr(true), AllowSquashMerge: ptr(false), AllowRebaseMerge: ptr(false)},
+			expectedMsg: "required linear history rejects merge commits",
+		},
+		{
+			name:        "MergeCommitsOnlyWithRuleset",
+			mode:        github.ProtectionRuleset,
+			ruleset:     &github.RulesetArgs{RequiredLinearHistory: ptr(true)},
+			merge:       &github.MergePolicy{AllowMergeCommit: ptr(true), AllowSquashMerge: ptr(false), AllowRebaseMerge: ptr(false)},
+			expectedMsg: "required linear history rejects merge commits",
+		},
+		{
+			// GitHub allows merge commits by default, and linear history rejects them.
+			name:        "UnsetMergeCommitsOnly",
+			merge:       &github.MergePolicy{AllowSquashMerge: ptr(false), AllowRebaseMerge: ptr(false)},
+			expectedMsg: "required linear history rejects merge commits",
+		},
+		{
+			name:        "TitleWithoutMessage",
+			merge:       &github.MergePolicy{SquashMergeCommitTitle: "PR_TITLE"},
+			expectedMsg: "squash merge commit title and message must be set together",
+		},
+		{
+			name:        "UnknownTitle",
+			merge:       &github.MergePolicy{SquashMergeCommitTitle: "TITLE", SquashMergeCommitMessage: "PR_BODY"},
+			expectedMsg: `unknown squash merge commit title "TITLE"`,
+		},
+		{
+			name:        "InvalidCombination",
+			merge:       &github.MergePolicy{SquashMergeCommitTitle: "COMMIT_OR_PR_TITLE", SquashMergeCommitMessage: "BLANK"},
+			expectedMsg: `squash merge commit title "COMMIT_OR_PR_TITLE" cannot be combined with message "BLANK"`,
+		},
+		{
+			name:        "FormatOfDisallowedMethod",
+			merge:       &github.MergePolicy{AllowMergeCommit: ptr(false), MergeCommitTitle: "MERGE_MESSAGE", MergeCommitMessage: "PR_TITLE"},
+			expectedMsg: "merge commit title and message require merge commits to be allowed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName: pulumi.String("test-repo"),
+					ProtectionMode: tt.mode,
+					Ruleset:        tt.ruleset,
+					MergePolicy:    tt.merge,
+				})
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			assert.ErrorContains(t, err, tt.expectedMsg)
+			assert.Empty(t, mocks.resources, "nothing should be registered for an invalid merge policy")
+		})
+	}
+}
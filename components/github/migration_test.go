@@ -0,0 +1,162 @@
+package github_test
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/softwaredevelop/pulumi-go-components/components/github"
+	"github.com/softwaredevelop/pulumi-go-components/pulumitest"
+)
+
+// urn returns the URN of a resource of the test stack.
+func urn(parent resource.URN, typ, name string) resource.URN {
+	return stack.URN(parent, typ, name)
+}
+
+// identities resolves the URN of every recorded resource together with the
+// URNs of its aliases, see pulumitest.Stack.Identities.
+func (m *recordingMocks) identities(t *testing.T) map[resource.URN]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	identities, err := stack.Identities(pulumitest.Registered(m.resources))
+	require.NoError(t, err)
+	return identities
+}
+
+func TestNewStandardRepo_MigratesWithoutReplacement(t *testing.T) {
+	const (
+		repositoryType = "github:index/repository:Repository"
+		protectionType = "github:index/branchProtection:BranchProtection"
+		labelType      = "github:index/issueLabel:IssueLabel"
+		secretType     = "github:index/actionsSecret:ActionsSecret"
+	)
+	tests := []struct {
+		name             string
+		legacyChildNames bool
+		component        resource.URN
+		children         map[string]string
+	}{
+		{
+			// The layout of the first release: a generic type token and fixed child names.
+			name:             "FixedChildNames",
+			legacyChildNames: true,
+			component:        urn("", "custom:resource:StandardRepo", "repo"),
+			children: map[string]string{
+				"repository":          repositoryType,
+				"branch-protection":   protectionType,
+				"label-gh-actions":    labelType,
+				"secret-gitlab-repo":  secretType,
+				"secret-gitlab-token": secretType,
+				"secret-gitlab-owner": secretType,
+			},
+		},
+		{
+			// Child names derived from the component name, under the generic token.
+			name:      "DerivedChildNames",
+			component: urn("", "custom:resource:StandardRepo", "repo"),
+			children: map[string]string{
+				"repo-repository":          repositoryType,
+				"repo-branch-protection":   protectionType,
+				"repo-label-gh-actions":    labelType,
+				"repo-secret-gitlab-repo":  secretType,
+				"repo-secret-gitlab-token": secretType,
+				"repo-secret-gitlab-owner": secretType,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName:   pulumi.String("test-repo"),
+					LegacyChildNames: tt.legacyChildNames,
+					Secrets: github.GitLabMirrorSecrets(
+						pulumi.String("group/project"), pulumi.String("token"), pulumi.String("owner")),
+				})
+				return err
+			}, pulumi.WithMocks(stack.Project, stack.Stack, mocks))
+			require.NoError(t, err)
+
+			// Every resource of the old layout must be matched by a resource of
+			// the new layout, so that the preview shows no replacement.
+			identities := mocks.identities(t)
+			assert.Equal(t, "repo", identities[tt.component], "the component should alias %s", tt.component)
+			for childName, childType := range tt.children {
+				old := urn(tt.component, childType, childName)
+				assert.Contains(t, identities, old, "no resource aliases %s", old)
+			}
+		})
+	}
+}
+
+func TestNewStandardRepo_MigratesWithDefaultArgs(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName:   pulumi.String("test-repo"),
+			Description:      pulumi.String("A repository of the first release"),
+			Topics:           pulumi.StringArray{pulumi.String("pulumi")},
+			LegacyChildNames: true,
+			Secrets: github.GitLabMirrorSecrets(
+				pulumi.String("group/project"), pulumi.String("token"), pulumi.String("owner")),
+		})
+		return err
+	}, pulumi.WithMocks(stack.Project, stack.Stack, mocks))
+	require.NoError(t, err)
+
+	// The arguments of the first release create the same resources, so that
+	// migrating a stack neither creates nor deletes any.
+	var children []string
+	for _, r := range mocks.resources {
+		if r.TypeToken != github.StandardRepoType {
+			children = append(children, r.Name)
+		}
+	}
+	assert.ElementsMatch(t, []string{
+		"repo-repository",
+		"repo-branch-protection",
+		"repo-label-gh-actions",
+		"repo-secret-gitlab-repo",
+		"repo-secret-gitlab-token",
+		"repo-secret-gitlab-owner",
+	}, children)
+
+	// The repository keeps the settings of the first release, and the
+	// settings it did not manage are left out.
+	repository := mocks.inputs("repo-repository")
+	assert.ElementsMatch(t, []resource.PropertyKey{
+		"name", "description", "topics", "visibility", "hasIssues", "hasProjects", "deleteBranchOnMerge",
+	}, repository.StableKeys())
+	assert.Equal(t, "public", repository["visibility"].StringValue())
+	assert.True(t, repository["hasIssues"].BoolValue())
+	assert.True(t, repository["hasProjects"].BoolValue())
+	assert.True(t, repository["deleteBranchOnMerge"].BoolValue())
+
+	protection := mocks.inputs("repo-branch-protection")
+	assert.Equal(t, "main", protection["pattern"].StringValue())
+	assert.True(t, protection["requiredLinearHistory"].BoolValue())
+}
+
+func TestNewStandardRepo_VersionedType(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+		})
+		return err
+	}, pulumi.WithMocks(stack.Project, stack.Stack, mocks))
+	require.NoError(t, err)
+
+	assert.Equal(t, "softwaredevelop:github/v1:StandardRepo", github.StandardRepoType)
+	assert.Equal(t, []string{"repo"}, mocks.names(github.StandardRepoType))
+	// The children are registered under the versioned token.
+	identities := mocks.identities(t)
+	assert.Contains(t, identities,
+		urn(urn("", github.StandardRepoType, "repo"), "github:index/repository:Repository", "repo-repository"))
+}
@@ -0,0 +1,42 @@
+package github
+
+import (
+	"slices"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// baseOptions returns the options shared by every child resource: the
+// component as parent, and the provider and transforms of the arguments.
+func baseOptions(parent pulumi.ResourceOrInvokeOption, args *StandardRepoArgs) []pulumi.ResourceOption {
+	opts := []pulumi.ResourceOption{parent}
+	if args.Provider != nil {
+		opts = append(opts, pulumi.Provider(args.Provider))
+	}
+	if len(args.Transforms) > 0 {
+		opts = append(opts, pulumi.Transforms(args.Transforms))
+	}
+	return opts
+}
+
+// invokeOptions returns the options of the lookups made by the component.
+func invokeOptions(parent pulumi.ResourceOrInvokeOption, args *StandardRepoArgs) []pulumi.InvokeOption {
+	opts := []pulumi.InvokeOption{parent}
+	if args.Provider != nil {
+		opts = append(opts, pulumi.Provider(args.Provider))
+	}
+	return opts
+}
+
+// childOptions extends the base options with protection and retention for
+// the children other than the repository, whose lifecycle is set separately.
+func childOptions(base []pulumi.ResourceOption, protect, retainOnDelete bool) []pulumi.ResourceOption {
+	opts := slices.Clone(base)
+	if protect {
+		opts = append(opts, pulumi.Protect(true))
+	}
+	if retainOnDelete {
+		opts = append(opts, pulumi.RetainOnDelete(true))
+	}
+	return opts
+}
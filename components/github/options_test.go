@@ -0,0 +1,112 @@
+package github_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pgithub "github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/softwaredevelop/pulumi-go-components/components/github"
+)
+
+// registerRPC returns the registration request recorded for the resource with
+// the given logical name.
+func (m *recordingMocks) registerRPC(t *testing.T, name string) pulumi.MockResourceArgs {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range m.resources {
+		if r.Name == name && r.RegisterRPC != nil {
+			return r
+		}
+	}
+	t.Fatalf("resource %s was not registered", name)
+	return pulumi.MockResourceArgs{}
+}
+
+func TestNewStandardRepo_Provider(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		provider, err := pgithub.NewProvider(ctx, "enterprise", &pgithub.ProviderArgs{
+			BaseUrl: pulumi.String("https://github.example.com/"),
+			Owner:   pulumi.String("platform"),
+		})
+		if err != nil {
+			return err
+		}
+		_, err = github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			AutoInit:       ptr(true),
+			Provider:       provider,
+			Secrets:        map[string]pulumi.StringInput{"TOKEN": pulumi.String("token")},
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	require.NoError(t, err)
+
+	for _, name := range []string{
+		"repo-repository",
+		"repo-default-branch",
+		"repo-branch-protection",
+		"repo-label-gh-actions",
+		"repo-secret-token",
+	} {
+		provider := mocks.registerRPC(t, name).RegisterRPC.GetProvider()
+		assert.True(t, strings.Contains(provider, "pulumi:providers:github::enterprise"),
+			"%s should use the explicit provider, got %q", name, provider)
+	}
+}
+
+func TestNewStandardRepo_ChildResourceOptions(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName:          pulumi.String("test-repo"),
+			AutoInit:                ptr(true),
+			RepositoryOptions:       []pulumi.ResourceOption{pulumi.IgnoreChanges([]string{"description"})},
+			BranchProtectionOptions: []pulumi.ResourceOption{pulumi.IgnoreChanges([]string{"requiredPullRequestReviews"})},
+			LabelOptions:            []pulumi.ResourceOption{pulumi.RetainOnDelete(true)},
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"description"}, mocks.registerRPC(t, "repo-repository").RegisterRPC.GetIgnoreChanges())
+	assert.Equal(t, []string{"requiredPullRequestReviews"}, mocks.registerRPC(t, "repo-branch-protection").RegisterRPC.GetIgnoreChanges())
+	// The options of one kind do not leak into the others.
+	assert.Empty(t, mocks.registerRPC(t, "repo-default-branch").RegisterRPC.GetIgnoreChanges())
+
+	_, retainOnDelete := mocks.registration("repo-label-gh-actions")
+	assert.True(t, retainOnDelete)
+	_, retainOnDelete = mocks.registration("repo-branch-protection")
+	assert.False(t, retainOnDelete)
+	// The component still orders the protection after the default branch.
+	assert.True(t, mocks.dependsOn("repo-branch-protection", "repo-default-branch"))
+}
+
+func TestNewStandardRepo_Transforms(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			AutoInit:       ptr(true),
+			Transforms: []pulumi.ResourceTransform{
+				func(_ context.Context, args *pulumi.ResourceTransformArgs) *pulumi.ResourceTransformResult {
+					return &pulumi.ResourceTransformResult{Props: args.Props, Opts: args.Opts}
+				},
+			},
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	require.NoError(t, err)
+
+	// The engine runs the transforms, so the mocks can only observe that every
+	// child registers them.
+	for _, name := range []string{"repo-repository", "repo-default-branch", "repo-branch-protection", "repo-label-gh-actions"} {
+		assert.Len(t, mocks.registerRPC(t, name).RegisterRPC.GetTransforms(), 1, "%s should register the transform", name)
+	}
+	assert.Empty(t, mocks.registerRPC(t, "repo").RegisterRPC.GetTransforms())
+}
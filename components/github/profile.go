@@ -0,0 +1,140 @@
+package github
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// RepositoryProfile names a predefined repository policy.
+type RepositoryProfile string
+
+const (
+	// ProfileOpenSource is a public repository open to outside contributions.
+	ProfileOpenSource RepositoryProfile = "open-source"
+	// ProfileInternal is a repository visible to the members of the enterprise.
+	ProfileInternal RepositoryProfile = "internal"
+	// ProfilePrivateConfidential is a private repository with every optional feature turned off.
+	ProfilePrivateConfidential RepositoryProfile = "private-confidential"
+)
+
+// RepositoryPolicy holds the repository settings selected by a profile.
+// Nil fields in an override keep the value of the profile, and settings that
+// remain nil are left to GitHub.
+type RepositoryPolicy struct {
+	// The visibility of the repository: "public", "private" or "internal".
+	Visibility *string
+	// Enable the GitHub Issues feature.
+	HasIssues *bool
+	// Enable the GitHub Projects feature.
+	HasProjects *bool
+	// Enable the GitHub Wiki feature.
+	HasWiki *bool
+	// Enable GitHub Discussions.
+	HasDiscussions *bool
+	// Allow merge commits on pull requests.
+	AllowMergeCommit *bool
+	// Allow squash merging on pull requests.
+	AllowSquashMerge *bool
+	// Allow rebase merging on pull requests.
+	AllowRebaseMerge *bool
+	// Automatically delete head branches after pull requests are merged.
+	DeleteBranchOnMerge *bool
+}
+
+// defaultPolicy is the policy of a repository without a profile. It only
+// holds the settings that the component managed before profiles existed, so
+// that the other settings of existing repositories are left as they are.
+var defaultPolicy = RepositoryPolicy{
+	Visibility:          ptr("public"),
+	HasIssues:           ptr(true),
+	HasProjects:         ptr(true),
+	DeleteBranchOnMerge: ptr(true),
+}
+
+// profiles holds the built-in repository profiles.
+var profiles = map[RepositoryProfile]RepositoryPolicy{
+	ProfileOpenSource: {
+		Visibility:          ptr("public"),
+		HasIssues:           ptr(true),
+		HasProjects:         ptr(true),
+		HasWiki:             ptr(false),
+		HasDiscussions:      ptr(true),
+		AllowMergeCommit:    ptr(false),
+		AllowSquashMerge:    ptr(true),
+		AllowRebaseMerge:    ptr(true),
+		DeleteBranchOnMerge: ptr(true),
+	},
+	ProfileInternal: {
+		Visibility:          ptr("internal"),
+		HasIssues:           ptr(true),
+		HasProjects:         ptr(true),
+		HasWiki:             ptr(true),
+		HasDiscussions:      ptr(false),
+		AllowMergeCommit:    ptr(false),
+		AllowSquashMerge:    ptr(true),
+		AllowRebaseMerge:    ptr(true),
+		DeleteBranchOnMerge: ptr(true),
+	},
+	ProfilePrivateConfidential: {
+		Visibility:          ptr("private"),
+		HasIssues:           ptr(true),
+		HasProjects:         ptr(false),
+		HasWiki:             ptr(false),
+		HasDiscussions:      ptr(false),
+		AllowMergeCommit:    ptr(false),
+		AllowSquashMerge:    ptr(true),
+		AllowRebaseMerge:    ptr(false),
+		DeleteBranchOnMerge: ptr(true),
+	},
+}
+
+// resolvePolicy looks up the given profile and applies the non-nil fields of
+// the override on top of it. An empty profile selects defaultPolicy.
+func resolvePolicy(profile RepositoryProfile, override *RepositoryPolicy) (RepositoryPolicy, error) {
+	policy := defaultPolicy
+	if profile != "" {
+		var ok bool
+		if policy, ok = profiles[profile]; !ok {
+			return RepositoryPolicy{}, fmt.Errorf("unknown repository profile %q", profile)
+		}
+	}
+	if override == nil {
+		return policy, nil
+	}
+
+	policy.apply(override)
+	if !slices.Contains(visibilities, *policy.Visibility) {
+		return RepositoryPolicy{}, fmt.Errorf("invalid visibility %q, expected one of %s",
+			*policy.Visibility, strings.Join(visibilities, ", "))
+	}
+	return policy, nil
+}
+
+// apply replaces the fields of the policy with the non-nil fields of the override.
+func (p *RepositoryPolicy) apply(override *RepositoryPolicy) {
+	overrideField(&p.Visibility, override.Visibility)
+	overrideField(&p.HasIssues, override.HasIssues)
+	overrideField(&p.HasProjects, override.HasProjects)
+	overrideField(&p.HasWiki, override.HasWiki)
+	overrideField(&p.HasDiscussions, override.HasDiscussions)
+	overrideField(&p.AllowMergeCommit, override.AllowMergeCommit)
+	overrideField(&p.AllowSquashMerge, override.AllowSquashMerge)
+	overrideField(&p.AllowRebaseMerge, override.AllowRebaseMerge)
+	overrideField(&p.DeleteBranchOnMerge, override.DeleteBranchOnMerge)
+}
+
+// visibilities are the visibilities a repository can have.
+var visibilities = []string{"public", "private", "internal"}
+
+// overrideField replaces dst with src when src is set.
+func overrideField[T any](dst **T, src *T) {
+	if src != nil {
+		*dst = src
+	}
+}
+
+// ptr returns a pointer to the given value.
+func ptr[T any](v T) *T {
+	return &v
+}
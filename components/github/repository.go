@@ -2,6 +2,8 @@
 package github
 
 import (
+	"slices"
+
 	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 )
@@ -15,6 +17,138 @@ type StandardRepoArgs struct {
 	Description pulumi.StringInput
 	// The topics to be assigned to the repository.
 	Topics pulumi.StringArrayInput
+	// Alias the children to the fixed names they had before child names were
+	// derived from the component name, so that a stack created with those
+	// names migrates without replacement. The fixed names are the same for
+	// every component, so at most one StandardRepo of a stack may set it.
+	LegacyChildNames bool
+	// Lowercase, deduplicate and sort the topics instead of rejecting
+	// uppercase and duplicate topics.
+	NormalizeTopics bool
+	// Create the repository with an initial commit, so that the default branch
+	// exists before it is protected. Nil leaves it to GitHub, which creates
+	// an empty repository. It does not apply to adopted repositories.
+	AutoInit *bool
+	// The gitignore template of the initial commit, such as "Go". Requires AutoInit.
+	GitignoreTemplate string
+	// The license template of the initial commit, such as "mit". Requires AutoInit.
+	LicenseTemplate string
+	// The name of the default branch. Defaults to "main". The default branch
+	// is only managed when it is set or AutoInit is enabled. With AutoInit,
+	// other names are created by renaming the branch of the initial commit,
+	// which GitHub names after the owner's default branch setting. Without
+	// it, the branch must already exist.
+	DefaultBranch string
+	// The policy profile of the repository. Without a profile, the repository
+	// is public with issues, projects and the deletion of merged branches
+	// enabled, and its other settings are left to GitHub.
+	Profile RepositoryProfile
+	// Overrides individual settings of the selected profile.
+	Policy *RepositoryPolicy
+	// Controls how pull requests are merged. Its merge methods take precedence
+	// over those of the profile and Policy.
+	MergePolicy *MergePolicy
+	// How the branches are protected. Defaults to ProtectionBranchProtection.
+	ProtectionMode ProtectionMode
+	// The ruleset created in ProtectionRuleset mode. Nil selects the default
+	// ruleset, which mirrors the classic protection of the default branch.
+	Ruleset *RulesetArgs
+	// The issue labels of the repository. They override catalog labels of the same name.
+	Labels []Label
+	// The built-in label catalogs to create. Without any catalog or label,
+	// only the "github-actions dependencies" label is created.
+	LabelCatalogs []LabelCatalog
+	// Manage the labels authoritatively, removing every label that is not
+	// declared. The labels are retained on delete in this mode, so that an
+	// existing stack can switch to it, or back, without losing labels.
+	AuthoritativeLabels bool
+	// The Actions secrets of the repository, keyed by secret name.
+	// The values are stored as Pulumi secrets. See GitLabMirrorSecrets for a preset.
+	Secrets map[string]pulumi.StringInput
+	// Encrypt the secret values client-side against the repository's Actions
+	// public key, and the environment secrets against the public key of their
+	// environment, so that plaintext values never reach the provider state.
+	// Every value is sealed with a fresh ephemeral key, so the secrets are
+	// updated on every deployment unless they are listed in StableSealedSecrets.
+	EncryptSecrets bool
+	// The names of the secrets whose ciphertext is derived deterministically
+	// from the public key and the value, so that an unchanged secret shows no
+	// diff. A guessed value can be confirmed against such a ciphertext, so
+	// only list high-entropy values such as tokens. Requires EncryptSecrets.
+	StableSealedSecrets []string
+	// The Actions variables of the repository, keyed by variable name.
+	// A variable must not share its name with a secret.
+	Variables map[string]pulumi.StringInput
+	// The deployment environments of the repository, such as staging and production.
+	Environments []Environment
+	// The teams granted access to the repository, keyed by team slug.
+	Teams map[string]Permission
+	// The users granted access to the repository, keyed by login.
+	Collaborators map[string]Permission
+	// Manage access authoritatively, revoking the access of every team and
+	// user that is not declared. The grants are retained on delete in this
+	// mode, so that an existing stack can switch to it, or back, without
+	// losing access.
+	AuthoritativeAccess bool
+	// The webhooks of the repository.
+	Webhooks []Webhook
+	// What happens to the repository when the component is deleted.
+	// Defaults to LifecycleDelete. LifecycleArchive does not apply to adopted
+	// repositories.
+	Lifecycle Lifecycle
+	// Protect the other child resources, so that deleting them fails until
+	// the protection is lifted.
+	ProtectChildren bool
+	// Leave the other child resources in place when they are deleted.
+	RetainChildrenOnDelete bool
+	// The provider of the child resources and lookups, for example to target
+	// another owner or a GitHub Enterprise Server. Defaults to the provider
+	// inherited from the component.
+	Provider pulumi.ProviderResource
+	// Transforms applied to every child resource.
+	Transforms []pulumi.ResourceTransform
+	// Additional options of each kind of child resource, such as IgnoreChanges
+	// or DependsOn. They are applied after the options set by the component,
+	// so they take precedence.
+	RepositoryOptions       []pulumi.ResourceOption
+	DefaultBranchOptions    []pulumi.ResourceOption
+	BranchProtectionOptions []pulumi.ResourceOption // Also applies to the ruleset.
+	LabelOptions            []pulumi.ResourceOption
+	SecretOptions           []pulumi.ResourceOption
+	VariableOptions         []pulumi.ResourceOption
+	EnvironmentOptions      []pulumi.ResourceOption // Also applies to their policies, secrets and variables.
+	AccessOptions           []pulumi.ResourceOption
+	WebhookOptions          []pulumi.ResourceOption
+	ActionsOptions          []pulumi.ResourceOption
+	FileOptions             []pulumi.ResourceOption
+	SecurityOptions         []pulumi.ResourceOption
+	// Locks down GitHub Actions in the repository. Nil leaves the Actions
+	// permissions unmanaged.
+	ActionsPolicy *ActionsPolicy
+	// The files committed to the default branch. See StandardFiles for the
+	// built-in templates. A ruleset that requires pull requests on the default
+	// branch needs a bypass actor that can always push for the committer.
+	Files []File
+	// The author of the commits that manage files. Defaults to the provider's author.
+	FileCommitAuthor *CommitAuthor
+	// Overwrite files that already exist in the repository when they are created.
+	OverwriteFilesOnCreate bool
+	// The package ecosystems kept up to date by Dependabot. A .github/dependabot.yml
+	// is rendered from them, labelling the pull requests with the labels of the
+	// dependabot catalog, which are created as needed.
+	Dependabot []DependabotUpdate
+	// Overrides the security and analysis settings, whose defaults depend on
+	// the visibility of the repository. Without a profile or an override, they
+	// are left unmanaged, except for the vulnerability alerts and security
+	// updates that Dependabot relies on.
+	Security *SecuritySettings
+	// Adopt an existing repository instead of creating it. The repository,
+	// its branch protection and its labels are imported with IDs derived from
+	// the repository name; missing protection rules and labels are created.
+	// Settings where the live repository differs from the standard are logged
+	// as warnings and reported in the Drift output, as the import fails until
+	// they are reconciled, for example through Policy.
+	Adopt bool
 }
 
 // StandardRepo is our custom component.
@@ -23,21 +157,163 @@ type StandardRepo struct {
 	pulumi.ResourceState
 
 	// Output properties that we want to access after using the component.
-	RepositoryName   pulumi.StringOutput `pulumi:"repositoryName"`
-	RepositoryURL    pulumi.StringOutput `pulumi:"repositoryUrl"`
-	RepositoryNodeID pulumi.StringOutput `pulumi:"repositoryNodeId"`
+	RepositoryName         pulumi.StringOutput `pulumi:"repositoryName"`
+	RepositoryURL          pulumi.StringOutput `pulumi:"repositoryUrl"`
+	RepositoryNodeID       pulumi.StringOutput `pulumi:"repositoryNodeId"`
+	RepositoryID           pulumi.IntOutput    `pulumi:"repositoryId"`
+	RepositoryFullName     pulumi.StringOutput `pulumi:"repositoryFullName"`
+	RepositorySSHCloneURL  pulumi.StringOutput `pulumi:"repositorySshCloneUrl"`
+	RepositoryHTTPCloneURL pulumi.StringOutput `pulumi:"repositoryHttpCloneUrl"`
+	DefaultBranch          pulumi.StringOutput `pulumi:"defaultBranch"`
 
 	// Expose the underlying repository resource to allow for composition.
 	Repository *github.Repository `pulumi:"repository"`
+	// The classic protection of the default branch, nil in ProtectionRuleset mode.
+	BranchProtection *github.BranchProtection `pulumi:"branchProtection"`
+	// The ruleset protecting the repository in ProtectionRuleset mode, nil otherwise.
+	Ruleset *github.RepositoryRuleset `pulumi:"ruleset"`
+	// The issue labels of the repository, keyed by label name. With
+	// AuthoritativeLabels, IssueLabels manages them as well.
+	Labels map[string]*github.IssueLabel `pulumi:"labels"`
+	// The resource managing all labels with AuthoritativeLabels, nil otherwise.
+	IssueLabels *github.IssueLabels `pulumi:"issueLabels"`
+	// The Actions secrets of the repository, keyed by secret name.
+	Secrets map[string]*github.ActionsSecret `pulumi:"secrets"`
+	// The deployment environments of the repository, keyed by environment name.
+	Environments map[string]*github.RepositoryEnvironment `pulumi:"environments"`
+	// The settings where the adopted repository differs from the standard.
+	// Only set in adopt mode.
+	Drift pulumi.StringArrayOutput `pulumi:"drift"`
 }
 
 // NewStandardRepo is the constructor function for our component.
 // It creates the component and the "child" resources within it.
 func NewStandardRepo(ctx *pulumi.Context, name string, args *StandardRepoArgs, opts ...pulumi.ResourceOption) (*StandardRepo, error) {
+	plan, err := resolveStandardRepo(args)
+	if err != nil {
+		return nil, err
+	}
+	return newStandardRepo(ctx, name, args, plan, opts...)
+}
+
+// standardRepoPlan holds the settings of a StandardRepo that are resolved
+// from its arguments before anything is registered.
+type standardRepoPlan struct {
+	topics   pulumi.StringArrayInput
+	policy   RepositoryPolicy
+	merge    MergePolicy
+	security SecuritySettings
+	autoInit bool
+	branch   string
+	labels   []Label
+	files    []File
+}
+
+// resolveStandardRepo validates the arguments of a StandardRepo and resolves
+// the settings they select.
+func resolveStandardRepo(args *StandardRepoArgs) (*standardRepoPlan, error) {
+	// Validate every input that is already known before anything is
+	// registered, instead of waiting for the GitHub API to reject it.
+	if err := validateRepositoryName(args.RepositoryName); err != nil {
+		return nil, err
+	}
+	topics, err := resolveTopics(args.Topics, args.NormalizeTopics)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := resolvePolicy(args.Profile, args.Policy)
+	if err != nil {
+		return nil, err
+	}
+	autoInit := args.AutoInit != nil && *args.AutoInit
+	branch := args.DefaultBranch
+	if branch == "" {
+		branch = defaultBranch
+	}
+	if err := validateInitialization(args.Adopt, autoInit, branch, args.GitignoreTemplate, args.LicenseTemplate); err != nil {
+		return nil, err
+	}
+	if err := validateProtection(args.ProtectionMode, args.Ruleset); err != nil {
+		return nil, err
+	}
+	merge, err := resolveMergePolicy(policy, args.MergePolicy, requiresLinearHistory(args.ProtectionMode, args.Ruleset))
+	if err != nil {
+		return nil, err
+	}
+	security, err := resolveSecurity(*policy.Visibility, args.Profile != "", args.Security, len(args.Dependabot) > 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateDependabot(args.Dependabot); err != nil {
+		return nil, err
+	}
+	labels, err := resolveLabels(args.LabelCatalogs, args.Labels)
+	if err != nil {
+		return nil, err
+	}
+	labels = withDependabotLabels(labels, args.Dependabot)
+	if err := validateLabels(labels); err != nil {
+		return nil, err
+	}
+	if err := validateSecrets(args.Secrets); err != nil {
+		return nil, err
+	}
+	if err := validateStableSealedSecrets(args.StableSealedSecrets, args.Secrets, args.EncryptSecrets); err != nil {
+		return nil, err
+	}
+	if err := validateVariables(args.Variables, args.Secrets); err != nil {
+		return nil, err
+	}
+	if err := validateEnvironments(args.Environments); err != nil {
+		return nil, err
+	}
+	if err := validateAccess(args.Teams, args.Collaborators); err != nil {
+		return nil, err
+	}
+	if err := validateWebhooks(args.Webhooks); err != nil {
+		return nil, err
+	}
+	if err := validateActionsPolicy(args.ActionsPolicy); err != nil {
+		return nil, err
+	}
+	if err := validateLifecycle(args.Lifecycle, args.Adopt); err != nil {
+		return nil, err
+	}
+	files := args.Files
+	if len(args.Dependabot) > 0 {
+		files = append(slices.Clone(files), dependabotFile(args.Dependabot))
+	}
+	if err := validateFiles(files, args.FileCommitAuthor); err != nil {
+		return nil, err
+	}
+	if err := validateFileCommits(files, args.ProtectionMode, args.Ruleset, branch); err != nil {
+		return nil, err
+	}
+
+	return &standardRepoPlan{
+		topics:   topics,
+		policy:   policy,
+		merge:    merge,
+		security: security,
+		autoInit: autoInit,
+		branch:   branch,
+		labels:   labels,
+		files:    files,
+	}, nil
+}
+
+// newStandardRepo registers a StandardRepo whose arguments were resolved into the plan.
+func newStandardRepo(ctx *pulumi.Context, name string, args *StandardRepoArgs, plan *standardRepoPlan, opts ...pulumi.ResourceOption) (*StandardRepo, error) {
+	topics, policy, merge, security := plan.topics, plan.policy, plan.merge, plan.security
+	autoInit, branch, labels, files := plan.autoInit, plan.branch, plan.labels, plan.files
+
 	// STEP 1: Register the component with the Pulumi engine.
-	// The first argument is a unique type name for Pulumi.
+	// The first argument is the versioned type token of the component. The
+	// former tokens are kept as aliases, so that existing stacks, including
+	// every child, migrate without replacement.
 	standardRepo := &StandardRepo{}
-	err := ctx.RegisterComponentResource("custom:resource:StandardRepo", name, standardRepo, opts...)
+	opts = append(slices.Clone(opts), typeAliases(legacyStandardRepoTypes))
+	err := ctx.RegisterComponentResource(StandardRepoType, name, standardRepo, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -45,83 +321,252 @@ func NewStandardRepo(ctx *pulumi.Context, name string, args *StandardRepoArgs, o
 	// STEP 2: Create a "parent" option. This ensures that all
 	// created resources are logically part of our component.
 	parentOpt := pulumi.Parent(standardRepo)
+	invokeOpts := invokeOptions(parentOpt, args)
+	baseOpts := baseOptions(parentOpt, args)
+	// The lifecycle governs the repository, the child options every other child.
+	childOpts := childOptions(baseOpts, args.ProtectChildren, args.RetainChildrenOnDelete)
 
 	// STEP 3: The full logic of `defineInfrastructure` is copied here,
 	// and the hardcoded values are replaced with those from `args`.
+	// Child names are derived from the component name so that several
+	// components can live in the same stack. With LegacyChildNames, the
+	// former fixed names are kept as aliases so that a stack created with
+	// them migrates without replacement.
+
+	// In adopt mode the live state decides which children are imported.
+	var adopt *adoption
+	repositoryOpts := append(slices.Clone(baseOpts), legacyAlias(args.LegacyChildNames, "repository"))
+	if args.Lifecycle == LifecycleProtect {
+		repositoryOpts = append(repositoryOpts, pulumi.Protect(true))
+	}
+	var archiveOnDestroy pulumi.BoolPtrInput
+	if args.Lifecycle == LifecycleArchive {
+		archiveOnDestroy = pulumi.Bool(true)
+	}
+	if args.Adopt {
+		adopt = newAdoption(ctx, args.RepositoryName, invokeOpts...)
+		repositoryOpts = append(repositoryOpts, adopt.importRepository())
+	}
+	repositoryOpts = append(repositoryOpts, args.RepositoryOptions...)
 
-	repository, err := github.NewRepository(ctx, "repository", &github.RepositoryArgs{
+	// AutoInit is not read back from GitHub, so it stays unset on adopted
+	// repositories to keep their import free of differences.
+	var autoInitInput pulumi.BoolPtrInput
+	if !args.Adopt {
+		autoInitInput = pulumi.BoolPtrFromPtr(args.AutoInit)
+	}
+	repository, err := github.NewRepository(ctx, childName(name, "repository"), &github.RepositoryArgs{
 		Name:                args.RepositoryName,
 		Description:         args.Description,
-		Topics:              args.Topics,
-		DeleteBranchOnMerge: pulumi.Bool(true),
-		HasIssues:           pulumi.Bool(true),
-		HasProjects:         pulumi.Bool(true),
-		Visibility:          pulumi.String("public"),
-	}, parentOpt) // Important: the component is the parent!
+		Topics:              topics,
+		ArchiveOnDestroy:    archiveOnDestroy,
+		AutoInit:            autoInitInput,
+		GitignoreTemplate:   stringPtr(args.GitignoreTemplate),
+		LicenseTemplate:     stringPtr(args.LicenseTemplate),
+		Visibility:          pulumi.StringPtrFromPtr(policy.Visibility),
+		HasIssues:           pulumi.BoolPtrFromPtr(policy.HasIssues),
+		HasProjects:         pulumi.BoolPtrFromPtr(policy.HasProjects),
+		HasWiki:             pulumi.BoolPtrFromPtr(policy.HasWiki),
+		HasDiscussions:      pulumi.BoolPtrFromPtr(policy.HasDiscussions),
+		AllowMergeCommit:    pulumi.BoolPtrFromPtr(merge.AllowMergeCommit),
+		AllowSquashMerge:    pulumi.BoolPtrFromPtr(merge.AllowSquashMerge),
+		AllowRebaseMerge:    pulumi.BoolPtrFromPtr(merge.AllowRebaseMerge),
+		AllowAutoMerge:      pulumi.BoolPtrFromPtr(merge.AllowAutoMerge),
+		AllowUpdateBranch:   pulumi.BoolPtrFromPtr(merge.AllowUpdateBranch),
+		DeleteBranchOnMerge: pulumi.BoolPtrFromPtr(policy.DeleteBranchOnMerge),
+		SecurityAndAnalysis: securityAndAnalysisArgs(*policy.Visibility, security),
+		VulnerabilityAlerts: pulumi.BoolPtrFromPtr(security.VulnerabilityAlerts),
+
+		MergeCommitTitle:         stringPtr(merge.MergeCommitTitle),
+		MergeCommitMessage:       stringPtr(merge.MergeCommitMessage),
+		SquashMergeCommitTitle:   stringPtr(merge.SquashMergeCommitTitle),
+		SquashMergeCommitMessage: stringPtr(merge.SquashMergeCommitMessage),
+	}, repositoryOpts...) // Important: the component is the parent!
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = github.NewBranchProtection(ctx, "branch-protection", &github.BranchProtectionArgs{
-		RepositoryId:          repository.NodeId,
-		Pattern:               pulumi.String("main"),
-		RequiredLinearHistory: pulumi.Bool(true),
-	}, parentOpt) // Important: the component is the parent!
+	// The default branch must exist before it is protected. It is only
+	// managed when the component creates it or it is named explicitly.
+	defaultBranchName := pulumi.String(branch).ToStringOutput()
+	var branchResources []pulumi.Resource
+	if autoInit || args.DefaultBranch != "" {
+		branchDefault, err := newDefaultBranch(ctx, name, repository.Name, branch, autoInit, slices.Concat(childOpts, args.DefaultBranchOptions)...)
+		if err != nil {
+			return nil, err
+		}
+		defaultBranchName = branchDefault.Branch
+		branchResources = append(branchResources, branchDefault)
+	}
+	protectionOpts := append(slices.Clone(childOpts), pulumi.DependsOn(branchResources))
+
+	if args.ProtectionMode == ProtectionRuleset {
+		ruleset, err := github.NewRepositoryRuleset(ctx, childName(name, "ruleset"),
+			rulesetArgs(repository.Name, args.Ruleset), append(protectionOpts, args.BranchProtectionOptions...)...)
+		if err != nil {
+			return nil, err
+		}
+		standardRepo.Ruleset = ruleset
+	} else {
+		protectionOpts = append(protectionOpts, legacyAlias(args.LegacyChildNames, "branch-protection"))
+		if adopt != nil {
+			protectionOpts = append(protectionOpts, adopt.importBranchProtection(branch))
+		}
+		protectionOpts = append(protectionOpts, args.BranchProtectionOptions...)
+		branchProtection, err := github.NewBranchProtection(ctx, childName(name, "branch-protection"), &github.BranchProtectionArgs{
+			RepositoryId:          repository.NodeId,
+			Pattern:               pulumi.String(branch),
+			RequiredLinearHistory: pulumi.Bool(true),
+		}, protectionOpts...) // Important: the component is the parent!
+		if err != nil {
+			return nil, err
+		}
+		standardRepo.BranchProtection = branchProtection
+	}
+
+	issueLabel, issueLabels, err := newLabels(ctx, name, repository.Name, labels, args.AuthoritativeLabels, args.LegacyChildNames, adopt, slices.Concat(childOpts, args.LabelOptions)...)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = github.NewIssueLabel(ctx, "label-gh-actions", &github.IssueLabelArgs{
-		Repository:  repository.Name,
-		Name:        pulumi.String("github-actions dependencies"),
-		Color:       pulumi.String("E66E01"),
-		Description: pulumi.String("This issue is related to github-actions dependencies"),
-	}, parentOpt) // Important: the component is the parent!
+	var publicKey pulumi.StringInput
+	if args.EncryptSecrets && len(args.Secrets) > 0 {
+		publicKey = github.GetActionsPublicKeyOutput(ctx, github.GetActionsPublicKeyOutputArgs{
+			Repository: repository.Name,
+		}, invokeOpts...).Key()
+	}
+	secrets, err := newSecrets(ctx, name, repository.Name, args.Secrets, publicKey, args.StableSealedSecrets, args.LegacyChildNames, slices.Concat(childOpts, args.SecretOptions)...)
 	if err != nil {
 		return nil, err
 	}
 
-	// The Parent was already correctly set for the secrets, but now it too
-	// must point to the component, not directly to the repository.
-	_, err = github.NewActionsSecret(ctx, "secret-gitlab-repo", &github.ActionsSecretArgs{
-		Repository: repository.Name,
-		SecretName: pulumi.String("GITLAB_REPOSITORY"),
-	}, parentOpt)
-	if err != nil {
+	if err := newVariables(ctx, name, repository.Name, args.Variables, slices.Concat(childOpts, args.VariableOptions)...); err != nil {
 		return nil, err
 	}
 
-	_, err = github.NewActionsSecret(ctx, "secret-gitlab-token", &github.ActionsSecretArgs{
-		Repository: repository.Name,
-		SecretName: pulumi.String("GITLAB_TOKEN"),
-	}, parentOpt)
+	var envPublicKey environmentPublicKey
+	if args.EncryptSecrets {
+		envPublicKey = func(environment pulumi.StringOutput) pulumi.StringInput {
+			return lookupEnvironmentPublicKey(ctx, repository.FullName, environment, invokeOpts...)
+		}
+	}
+	environments, err := newEnvironments(ctx, name, repository.Name, args.Environments, envPublicKey, slices.Concat(childOpts, args.EnvironmentOptions)...)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = github.NewActionsSecret(ctx, "secret-gitlab-owner", &github.ActionsSecretArgs{
-		Repository: repository.Name,
-		SecretName: pulumi.String("GITLAB_OWNER"),
-	}, parentOpt)
-	if err != nil {
+	if err := newAccess(ctx, name, repository.Name, args.Teams, args.Collaborators, args.AuthoritativeAccess, slices.Concat(childOpts, args.AccessOptions)...); err != nil {
+		return nil, err
+	}
+
+	if err := newWebhooks(ctx, name, repository.Name, args.Webhooks, slices.Concat(childOpts, args.WebhookOptions)...); err != nil {
 		return nil, err
 	}
 
+	if args.ActionsPolicy != nil {
+		if err := newActionsPermissions(ctx, name, repository.Name, args.ActionsPolicy, slices.Concat(childOpts, args.ActionsOptions)...); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := newFiles(ctx, name, repository.Name, defaultBranchName, files, args.FileCommitAuthor, args.OverwriteFilesOnCreate, slices.Concat(childOpts, args.FileOptions)...); err != nil {
+		return nil, err
+	}
+
+	if security.DependabotSecurityUpdates != nil {
+		if err := newDependabotSecurityUpdates(ctx, name, repository.Name, *security.DependabotSecurityUpdates, slices.Concat(childOpts, args.SecurityOptions)...); err != nil {
+			return nil, err
+		}
+	}
+
 	// STEP 4: Set the output properties of the component.
 	standardRepo.RepositoryName = repository.Name
 	standardRepo.RepositoryURL = repository.HtmlUrl
 	standardRepo.RepositoryNodeID = repository.NodeId
+	standardRepo.RepositoryID = repository.RepoId
+	standardRepo.RepositoryFullName = repository.FullName
+	standardRepo.RepositorySSHCloneURL = repository.SshCloneUrl
+	standardRepo.RepositoryHTTPCloneURL = repository.HttpCloneUrl
+	standardRepo.DefaultBranch = defaultBranchName
 	standardRepo.Repository = repository
+	standardRepo.Labels = issueLabel
+	standardRepo.IssueLabels = issueLabels
+	standardRepo.Secrets = secrets
+	standardRepo.Environments = environments
+	if adopt != nil {
+		standardRepo.Drift = adopt.drift(ctx, standardRepo, policy, merge, security, branch, labels, args.Description, args.Topics)
+	}
 
 	// STEP 5: Register the outputs so the Pulumi engine can see them.
-	if err := ctx.RegisterResourceOutputs(standardRepo, pulumi.Map{
-		"repositoryName":   standardRepo.RepositoryName,
-		"repositoryUrl":    standardRepo.RepositoryURL,
-		"repositoryNodeId": standardRepo.RepositoryNodeID,
-		"repository":       standardRepo.Repository,
-	}); err != nil {
+	if err := ctx.RegisterResourceOutputs(standardRepo, standardRepo.outputs()); err != nil {
 		return nil, err
 	}
 
 	return standardRepo, nil
 }
+
+// outputs returns the output properties of the component, omitting the
+// children that were not created.
+func (r *StandardRepo) outputs() pulumi.Map {
+	outputs := pulumi.Map{
+		"repositoryName":         r.RepositoryName,
+		"repositoryUrl":          r.RepositoryURL,
+		"repositoryNodeId":       r.RepositoryNodeID,
+		"repositoryId":           r.RepositoryID,
+		"repositoryFullName":     r.RepositoryFullName,
+		"repositorySshCloneUrl":  r.RepositorySSHCloneURL,
+		"repositoryHttpCloneUrl": r.RepositoryHTTPCloneURL,
+		"defaultBranch":          r.DefaultBranch,
+		"repository":             r.Repository,
+		"labels":                 resourceOutputs(r.Labels),
+		"secrets":                resourceOutputs(r.Secrets),
+		"environments":           resourceOutputs(r.Environments),
+	}
+	if r.BranchProtection != nil {
+		outputs["branchProtection"] = r.BranchProtection
+	}
+	if r.Ruleset != nil {
+		outputs["ruleset"] = r.Ruleset
+	}
+	if r.IssueLabels != nil {
+		outputs["issueLabels"] = r.IssueLabels
+	}
+	if r.Drift.OutputState != nil {
+		outputs["drift"] = r.Drift
+	}
+	return outputs
+}
+
+// resourceOutputs converts the child resources into a map for RegisterResourceOutputs.
+func resourceOutputs[T pulumi.Input](resources map[string]T) pulumi.Map {
+	outputs := pulumi.Map{}
+	for key, resource := range resources {
+		outputs[key] = resource
+	}
+	return outputs
+}
+
+// childName derives the logical name of a child resource from the name of the
+// component, so that multiple components can coexist in a single stack.
+func childName(name, suffix string) string {
+	return name + "-" + suffix
+}
+
+// legacyAlias returns an alias pointing to the fixed logical name that a child
+// resource had before child names were derived from the component name, or
+// no alias unless enabled. The parent and type of the alias default to those
+// of the child itself.
+func legacyAlias(enabled bool, legacyName string) pulumi.ResourceOption {
+	if !enabled {
+		return pulumi.Aliases(nil)
+	}
+	return pulumi.Aliases([]pulumi.Alias{{Name: pulumi.String(legacyName)}})
+}
+
+// stringPtr returns nil for the empty string, leaving the input unset.
+func stringPtr(s string) pulumi.StringPtrInput {
+	if s == "" {
+		return nil
+	}
+	return pulumi.String(s)
+}
@@ -2,13 +2,19 @@ package github_test
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/softwaredevelop/pulumi-go-components/components/github"
+	"github.com/softwaredevelop/pulumi-go-components/pulumitest"
 )
 
 // standardRepoMocks implements the pulumi.Mock interface for component testing.
@@ -23,23 +29,47 @@ func (m standardRepoMocks) NewResource(args pulumi.MockResourceArgs) (string, re
 
 	// Handle the creation of child resources within the component.
 	switch args.TypeToken {
-	case "custom:resource:StandardRepo":
+	case github.StandardRepoType, github.StandardRepoSetType:
 		// The component resource itself doesn't need to mock any outputs.
 		// Its outputs are constructed from its child resources.
+	case "pulumi:providers:github":
+		// Explicit providers are passed to the component by some tests.
 	case "github:index/repository:Repository":
 		// These outputs are used by the component's outputs.
 		// It's crucial to mock them.
 		repoName := args.Inputs["name"].StringValue()
 		outputs["name"] = repoName
+		outputs["fullName"] = "mock-owner/" + repoName
 		outputs["htmlUrl"] = fmt.Sprintf("https://github.com/mock-owner/%s", repoName)
 		outputs["nodeId"] = "mock-node-id-for-" + args.Name
+		outputs["repoId"] = 42
+		outputs["fullName"] = "mock-owner/" + repoName
+		outputs["sshCloneUrl"] = fmt.Sprintf("git@github.com:mock-owner/%s.git", repoName)
+		outputs["httpCloneUrl"] = fmt.Sprintf("https://github.com/mock-owner/%s.git", repoName)
 
 	// For the other resources, we don't need to mock specific outputs
 	// as the component does not directly depend on them. It's enough
 	// that their creation succeeds without error.
+	case "github:index/branchDefault:BranchDefault":
+		outputs["branch"] = args.Inputs["branch"]
 	case "github:index/branchProtection:BranchProtection":
+	case "github:index/repositoryRuleset:RepositoryRuleset":
 	case "github:index/issueLabel:IssueLabel":
+	case "github:index/issueLabels:IssueLabels":
 	case "github:index/actionsSecret:ActionsSecret":
+	case "github:index/actionsVariable:ActionsVariable":
+	case "github:index/repositoryEnvironment:RepositoryEnvironment":
+		outputs["environment"] = args.Inputs["environment"]
+	case "github:index/repositoryEnvironmentDeploymentPolicy:RepositoryEnvironmentDeploymentPolicy":
+	case "github:index/actionsEnvironmentSecret:ActionsEnvironmentSecret":
+	case "github:index/actionsEnvironmentVariable:ActionsEnvironmentVariable":
+	case "github:index/teamRepository:TeamRepository":
+	case "github:index/repositoryCollaborator:RepositoryCollaborator":
+	case "github:index/repositoryCollaborators:RepositoryCollaborators":
+	case "github:index/repositoryWebhook:RepositoryWebhook":
+	case "github:index/repositoryFile:RepositoryFile":
+	case "github:index/actionsRepositoryPermissions:ActionsRepositoryPermissions":
+	case "github:index/repositoryDependabotSecurityUpdates:RepositoryDependabotSecurityUpdates":
 
 	default:
 		return "", nil, fmt.Errorf("unknown resource type: %s", args.TypeToken)
@@ -50,11 +80,131 @@ func (m standardRepoMocks) NewResource(args pulumi.MockResourceArgs) (string, re
 	return id, resource.NewPropertyMapFromMap(outputs), nil
 }
 
+// mockActionsPublicKey is the Actions public key returned by the mocked provider.
+const mockActionsPublicKey = "B6N8vBQgk8i3VdwbEOhstCY3StFqqFPtC9/AsrhtHHw="
+
 // Call egy mock implementációt biztosít a függvény/provider hívásokhoz.
-func (m standardRepoMocks) Call(_ pulumi.MockCallArgs) (resource.PropertyMap, error) {
+func (m standardRepoMocks) Call(args pulumi.MockCallArgs) (resource.PropertyMap, error) {
+	switch args.Token {
+	case "github:index/getActionsPublicKey:getActionsPublicKey":
+		return resource.NewPropertyMapFromMap(map[string]any{
+			"key":        mockActionsPublicKey,
+			"keyId":      "mock-key-id",
+			"repository": args.Args["repository"],
+		}), nil
+	case "github:index/getRestApi:getRestApi":
+		endpoint := args.Args["endpoint"].StringValue()
+		if strings.HasSuffix(endpoint, "/secrets/public-key") {
+			return resource.NewPropertyMapFromMap(map[string]any{
+				"body":     fmt.Sprintf(`{"key_id":"mock-key-id","key":%q}`, mockActionsPublicKey),
+				"code":     200,
+				"endpoint": endpoint,
+				"status":   "200 OK",
+			}), nil
+		}
+	}
 	return resource.PropertyMap{}, nil
 }
 
+// recordingMocks wraps standardRepoMocks and records every resource registration,
+// so tests can assert on logical names and resource options.
+type recordingMocks struct {
+	standardRepoMocks
+
+	mu        sync.Mutex
+	resources []pulumi.MockResourceArgs
+}
+
+// NewResource records the registration and delegates to standardRepoMocks.
+func (m *recordingMocks) NewResource(args pulumi.MockResourceArgs) (string, resource.PropertyMap, error) {
+	m.mu.Lock()
+	m.resources = append(m.resources, args)
+	m.mu.Unlock()
+	return m.standardRepoMocks.NewResource(args)
+}
+
+// names returns the sorted logical names of the recorded resources of the given type.
+func (m *recordingMocks) names(typeToken string) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var names []string
+	for _, r := range m.resources {
+		if r.TypeToken == typeToken {
+			names = append(names, r.Name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// inputs returns the inputs recorded for the resource with the given logical name.
+func (m *recordingMocks) inputs(name string) resource.PropertyMap {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range m.resources {
+		if r.Name == name {
+			return r.Inputs
+		}
+	}
+	return nil
+}
+
+// retainOnDelete reports whether the resource with the given logical name is retained on delete.
+func (m *recordingMocks) retainOnDelete(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range m.resources {
+		if r.Name == name && r.RegisterRPC != nil {
+			return r.RegisterRPC.GetRetainOnDelete()
+		}
+	}
+	return false
+}
+
+// aliasNames returns the alias names recorded for the resource with the given logical name.
+func (m *recordingMocks) aliasNames(name string) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var names []string
+	for _, r := range m.resources {
+		if r.Name != name || r.RegisterRPC == nil {
+			continue
+		}
+		for _, alias := range r.RegisterRPC.GetAliases() {
+			if spec := alias.GetSpec(); spec != nil {
+				names = append(names, spec.GetName())
+			}
+		}
+	}
+	return names
+}
+
+// stack is the stack of the mocks.
+var stack = pulumitest.Stack{Project: "test-project", Stack: "test-stack"}
+
+// dependsOn reports whether the resource with the given logical name depends
+// on the resource with the logical name dependency.
+func (m *recordingMocks) dependsOn(name, dependency string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range m.resources {
+		if r.Name != name || r.RegisterRPC == nil {
+			continue
+		}
+		for _, urn := range r.RegisterRPC.GetDependencies() {
+			if strings.HasSuffix(urn, "::"+dependency) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ptr returns a pointer to the given value.
+func ptr[T any](v T) *T {
+	return &v
+}
+
 // assertOutputEquals is a helper function to reduce boilerplate in tests.
 func assertOutputEquals[T any](t *testing.T, output pulumi.Output, expected T, msgAndArgs ...any) {
 	t.Helper()
@@ -82,8 +232,8 @@ func TestNewStandardRepo(t *testing.T) {
 		// The values are derived from the mocked Repository resource.
 		assertOutputEquals(t, repo.RepositoryName, "test-repo", "RepositoryName should match the input")
 		assertOutputEquals(t, repo.RepositoryURL, "https://github.com/mock-owner/test-repo", "RepositoryURL should be the mocked URL")
-		// The child `repository` resource has the logical name "repository", so the mocked Node ID will contain it.
-		assertOutputEquals(t, repo.RepositoryNodeID, "mock-node-id-for-repository", "RepositoryNodeID should be the mocked Node ID")
+		// The child `repository` resource's logical name is derived from the component name, so the mocked Node ID will contain it.
+		assertOutputEquals(t, repo.RepositoryNodeID, "mock-node-id-for-testStandardRepo-repository", "RepositoryNodeID should be the mocked Node ID")
 		assert.NotNil(t, repo.Repository, "The underlying Repository resource should be exposed")
 
 		return nil
@@ -91,3 +241,284 @@ func TestNewStandardRepo(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestNewStandardRepo_Outputs(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		repo, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			DefaultBranch:  "trunk",
+			Secrets:        map[string]pulumi.StringInput{"NPM_TOKEN": pulumi.String("token")},
+		})
+		require.NoError(t, err)
+
+		assertOutputEquals(t, repo.RepositoryID, 42)
+		assertOutputEquals(t, repo.RepositoryFullName, "mock-owner/test-repo")
+		assertOutputEquals(t, repo.RepositorySSHCloneURL, "git@github.com:mock-owner/test-repo.git")
+		assertOutputEquals(t, repo.RepositoryHTTPCloneURL, "https://github.com/mock-owner/test-repo.git")
+		assertOutputEquals(t, repo.DefaultBranch, "trunk")
+
+		assert.NotNil(t, repo.BranchProtection)
+		assert.Nil(t, repo.Ruleset)
+		assert.Nil(t, repo.IssueLabels)
+		assert.Equal(t, []string{"github-actions dependencies"}, slices.Collect(maps.Keys(repo.Labels)))
+		assert.Equal(t, []string{"NPM_TOKEN"}, slices.Collect(maps.Keys(repo.Secrets)))
+		return nil
+	}, pulumi.WithMocks("test-project", "test-stack", standardRepoMocks(0)))
+	assert.NoError(t, err)
+}
+
+func TestNewStandardRepo_AuthoritativeLabelsOutput(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		repo, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName:      pulumi.String("test-repo"),
+			AuthoritativeLabels: true,
+			ProtectionMode:      github.ProtectionRuleset,
+		})
+		require.NoError(t, err)
+
+		assert.NotNil(t, repo.IssueLabels)
+		// The labels remain individual resources alongside IssueLabels.
+		assert.Equal(t, []string{"github-actions dependencies"}, slices.Collect(maps.Keys(repo.Labels)))
+		assert.NotNil(t, repo.Ruleset)
+		assert.Nil(t, repo.BranchProtection)
+		return nil
+	}, pulumi.WithMocks("test-project", "test-stack", standardRepoMocks(0)))
+	assert.NoError(t, err)
+}
+
+func TestNewStandardRepo_MultipleInstances(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		for _, name := range []string{"first", "second"} {
+			_, err := github.NewStandardRepo(ctx, name, &github.StandardRepoArgs{
+				RepositoryName: pulumi.String(name + "-repo"),
+				Description:    pulumi.String("A test repository"),
+			})
+			assert.NoError(t, err)
+		}
+		return nil
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"first-repository", "second-repository"}, mocks.names("github:index/repository:Repository"))
+	assert.Equal(t, []string{"first-branch-protection", "second-branch-protection"}, mocks.names("github:index/branchProtection:BranchProtection"))
+	assert.Equal(t, []string{"first-label-gh-actions", "second-label-gh-actions"}, mocks.names("github:index/issueLabel:IssueLabel"))
+
+	// Without LegacyChildNames, no child claims the former fixed names.
+	for _, name := range mocks.names("github:index/repository:Repository") {
+		assert.Empty(t, mocks.aliasNames(name))
+	}
+	identities, err := stack.Identities(pulumitest.Registered(mocks.resources))
+	assert.NoError(t, err)
+	assert.NotContains(t, identities, stack.URN(stack.URN("", "custom:resource:StandardRepo", "first"), "github:index/repository:Repository", "repository"))
+}
+
+func TestNewStandardRepo_LegacyChildNames(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		for _, name := range []string{"legacy", "other"} {
+			_, err := github.NewStandardRepo(ctx, name, &github.StandardRepoArgs{
+				RepositoryName:   pulumi.String(name + "-repo"),
+				Secrets:          github.GitLabMirrorSecrets(pulumi.String("group/repo"), pulumi.String("token"), pulumi.String("group")),
+				LegacyChildNames: name == "legacy",
+			})
+			assert.NoError(t, err)
+		}
+		return nil
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	// The former fixed names are kept as aliases so that an existing stack migrates in place.
+	assert.Equal(t, []string{"repository"}, mocks.aliasNames("legacy-repository"))
+	assert.Equal(t, []string{"branch-protection"}, mocks.aliasNames("legacy-branch-protection"))
+	assert.Equal(t, []string{"label-gh-actions"}, mocks.aliasNames("legacy-label-gh-actions"))
+	assert.Equal(t, []string{"secret-gitlab-token"}, mocks.aliasNames("legacy-secret-gitlab-token"))
+	assert.Empty(t, mocks.aliasNames("other-repository"))
+
+	// Only the component that opted in claims the former URNs.
+	identities, err := stack.Identities(pulumitest.Registered(mocks.resources))
+	assert.NoError(t, err)
+	legacy := stack.URN("", "custom:resource:StandardRepo", "legacy")
+	for typ, name := range map[string]string{
+		"github:index/repository:Repository":             "repository",
+		"github:index/branchProtection:BranchProtection": "branch-protection",
+		"github:index/issueLabel:IssueLabel":             "label-gh-actions",
+		"github:index/actionsSecret:ActionsSecret":       "secret-gitlab-owner",
+	} {
+		assert.Equal(t, "legacy-"+name, identities[stack.URN(legacy, typ, name)])
+	}
+}
+
+func TestNewStandardRepo_Profiles(t *testing.T) {
+	tests := []struct {
+		name             string
+		profile          github.RepositoryProfile
+		policy           *github.RepositoryPolicy
+		expectVisibility string
+		expectWiki       bool
+		expectProjects   bool
+		expectRebase     bool
+	}{
+		{"OpenSource", github.ProfileOpenSource, nil, "public", false, true, true},
+		{"Internal", github.ProfileInternal, nil, "internal", true, true, true},
+		{"PrivateConfidential", github.ProfilePrivateConfidential, nil, "private", false, false, false},
+		{
+			"OverrideSingleField",
+			github.ProfilePrivateConfidential,
+			&github.RepositoryPolicy{HasWiki: ptr(true)},
+			"private", true, false, false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName: pulumi.String("test-repo"),
+					Profile:        tt.profile,
+					Policy:         tt.policy,
+				})
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			assert.NoError(t, err)
+
+			inputs := mocks.inputs("repo-repository")
+			assert.Equal(t, tt.expectVisibility, inputs["visibility"].StringValue())
+			assert.Equal(t, tt.expectWiki, inputs["hasWiki"].BoolValue())
+			assert.Equal(t, tt.expectProjects, inputs["hasProjects"].BoolValue())
+			assert.Equal(t, tt.expectRebase, inputs["allowRebaseMerge"].BoolValue())
+			assert.True(t, inputs["deleteBranchOnMerge"].BoolValue())
+		})
+	}
+}
+
+func TestNewStandardRepo_DefaultPolicy(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	// Without a profile, only the settings managed before profiles existed
+	// are sent, and the others are left to GitHub.
+	assert.Equal(t, map[string]any{
+		"name":                "test-repo",
+		"visibility":          "public",
+		"hasIssues":           true,
+		"hasProjects":         true,
+		"deleteBranchOnMerge": true,
+	}, mocks.inputs("repo-repository").Mappable())
+}
+
+func TestNewStandardRepo_UnknownProfile(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			Profile:        "closed-source",
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+
+	assert.ErrorContains(t, err, `unknown repository profile "closed-source"`)
+	assert.Empty(t, mocks.names(github.StandardRepoType), "nothing should be registered for invalid input")
+}
+
+func TestNewStandardRepo_InvalidVisibility(t *testing.T) {
+	for _, visibility := range []string{"Public", ""} {
+		t.Run(visibility, func(t *testing.T) {
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName: pulumi.String("test-repo"),
+					Policy:         &github.RepositoryPolicy{Visibility: ptr(visibility)},
+				})
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+
+			assert.ErrorContains(t, err, fmt.Sprintf("invalid visibility %q, expected one of public, private, internal", visibility))
+			assert.Empty(t, mocks.resources, "nothing should be registered for invalid input")
+		})
+	}
+}
+
+func TestNewStandardRepo_Ruleset(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		repo, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			ProtectionMode: github.ProtectionRuleset,
+			Ruleset: &github.RulesetArgs{
+				Include:              []string{"~DEFAULT_BRANCH", "refs/heads/release/*"},
+				Exclude:              []string{"refs/heads/release/old"},
+				RequiredReviews:      &github.RequiredReviews{ApprovingReviewCount: 2, RequireCodeOwnerReview: true},
+				RequiredStatusChecks: &github.RequiredStatusChecks{Contexts: []string{"revive", "actionlint"}, Strict: true},
+				RequiredSignatures:   true,
+				BypassActors:         []github.BypassActor{{ActorID: 5, ActorType: "RepositoryRole"}},
+			},
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, repo.Ruleset, "The ruleset should be exposed in ruleset mode")
+		return nil
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	assert.Empty(t, mocks.names("github:index/branchProtection:BranchProtection"), "classic protection must not be created in ruleset mode")
+	assert.Equal(t, []string{"repo-ruleset"}, mocks.names("github:index/repositoryRuleset:RepositoryRuleset"))
+
+	inputs := mocks.inputs("repo-ruleset")
+	assert.Equal(t, "active", inputs["enforcement"].StringValue())
+	refName := inputs["conditions"].ObjectValue()["refName"].ObjectValue()
+	assert.Len(t, refName["includes"].ArrayValue(), 2)
+	assert.Len(t, refName["excludes"].ArrayValue(), 1)
+	rules := inputs["rules"].ObjectValue()
+	assert.True(t, rules["requiredSignatures"].BoolValue())
+	assert.True(t, rules["requiredLinearHistory"].BoolValue())
+	assert.Equal(t, 2.0, rules["pullRequest"].ObjectValue()["requiredApprovingReviewCount"].NumberValue())
+	assert.Len(t, rules["requiredStatusChecks"].ObjectValue()["requiredChecks"].ArrayValue(), 2)
+	bypass := inputs["bypassActors"].ArrayValue()
+	assert.Len(t, bypass, 1)
+	assert.Equal(t, "always", bypass[0].ObjectValue()["bypassMode"].StringValue())
+}
+
+func TestNewStandardRepo_InvalidProtection(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        *github.StandardRepoArgs
+		expectedMsg string
+	}{
+		{
+			"UnknownMode",
+			&github.StandardRepoArgs{ProtectionMode: "none"},
+			`unknown protection mode "none"`,
+		},
+		{
+			"RulesetWithoutRulesetMode",
+			&github.StandardRepoArgs{Ruleset: &github.RulesetArgs{}},
+			`ruleset arguments require protection mode "ruleset"`,
+		},
+		{
+			"UnknownBypassActor",
+			&github.StandardRepoArgs{
+				ProtectionMode: github.ProtectionRuleset,
+				Ruleset:        &github.RulesetArgs{BypassActors: []github.BypassActor{{ActorID: 1, ActorType: "User"}}},
+			},
+			`unknown bypass actor type "User"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				tt.args.RepositoryName = pulumi.String("test-repo")
+				_, err := github.NewStandardRepo(ctx, "repo", tt.args)
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", standardRepoMocks(0)))
+			assert.ErrorContains(t, err, tt.expectedMsg)
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package github
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// ProtectionMode selects how the branches of the repository are protected.
+type ProtectionMode string
+
+const (
+	// ProtectionBranchProtection protects the main branch with a classic
+	// branch protection rule. It is the default mode when none is given.
+	ProtectionBranchProtection ProtectionMode = "branch-protection"
+	// ProtectionRuleset protects the branches matched by RulesetArgs with a
+	// repository ruleset.
+	ProtectionRuleset ProtectionMode = "ruleset"
+)
+
+// defaultBranchRef is the ruleset ref pattern that matches the default branch.
+const defaultBranchRef = "~DEFAULT_BRANCH"
+
+// RulesetArgs defines the repository ruleset created in ProtectionRuleset mode.
+type RulesetArgs struct {
+	// The name of the ruleset. Defaults to "default-branch".
+	Name string
+	// The enforcement status: "active", "evaluate" or "disabled". Defaults to "active".
+	Enforcement string
+	// The ref patterns the ruleset applies to. Defaults to the default branch.
+	Include []string
+	// The ref patterns excluded from the ruleset.
+	Exclude []string
+	// Require pull request reviews before merging. Nil disables the rule.
+	RequiredReviews *RequiredReviews
+	// Require status checks to pass before merging. Nil disables the rule.
+	RequiredStatusChecks *RequiredStatusChecks
+	// Require commits to have verified signatures.
+	RequiredSignatures bool
+	// Prevent merge commits from being pushed. Defaults to true.
+	RequiredLinearHistory *bool
+	// The actors that are allowed to bypass the ruleset.
+	BypassActors []BypassActor
+}
+
+// RequiredReviews defines the pull request rule of a ruleset.
+type RequiredReviews struct {
+	// The number of approving reviews required before merging.
+	ApprovingReviewCount int
+	// Dismiss approving reviews when new commits are pushed.
+	DismissStaleReviewsOnPush bool
+	// Require an approving review from a code owner.
+	RequireCodeOwnerReview bool
+	// Require the most recent push to be approved by someone other than its author.
+	RequireLastPushApproval bool
+	// Require all review conversations to be resolved before merging.
+	RequireThreadResolution bool
+}
+
+// RequiredStatusChecks defines the status check rule of a ruleset.
+type RequiredStatusChecks struct {
+	// The status check contexts that must pass.
+	Contexts []string
+	// Require branches to be up to date with the base branch before merging.
+	Strict bool
+}
+
+// BypassActor is an actor allowed to bypass a ruleset.
+type BypassActor struct {
+	// The ID of the actor.
+	ActorID int
+	// The type of the actor: "RepositoryRole", "Team", "Integration",
+	// "OrganizationAdmin" or "DeployKey".
+	ActorType string
+	// When the actor can bypass the ruleset: "always" or "pull_request". Defaults to "always".
+	BypassMode string
+}
+
+var (
+	rulesetEnforcements = []string{"active", "evaluate", "disabled"}
+	bypassActorTypes    = []string{"RepositoryRole", "Team", "Integration", "OrganizationAdmin", "DeployKey"}
+	bypassModes         = []string{"always", "pull_request"}
+)
+
+// validateProtection checks the protection mode and, in ruleset mode, the ruleset arguments.
+func validateProtection(mode ProtectionMode, ruleset *RulesetArgs) error {
+	switch mode {
+	case "", ProtectionBranchProtection:
+		if ruleset != nil {
+			return fmt.Errorf("ruleset arguments require protection mode %q", ProtectionRuleset)
+		}
+		return nil
+	case ProtectionRuleset:
+	default:
+		return fmt.Errorf("unknown protection mode %q", mode)
+	}
+	if ruleset == nil {
+		return nil
+	}
+
+	if ruleset.Enforcement != "" && !slices.Contains(rulesetEnforcements, ruleset.Enforcement) {
+		return fmt.Errorf("unknown ruleset enforcement %q", ruleset.Enforcement)
+	}
+	for _, pattern := range append(slices.Clone(ruleset.Include), ruleset.Exclude...) {
+		if pattern == "" {
+			return fmt.Errorf("ruleset ref patterns must not be empty")
+		}
+	}
+	if reviews := ruleset.RequiredReviews; reviews != nil && (reviews.ApprovingReviewCount < 0 || reviews.ApprovingReviewCount > 10) {
+		return fmt.Errorf("required approving review count must be between 0 and 10, got %d", reviews.ApprovingReviewCount)
+	}
+	if checks := ruleset.RequiredStatusChecks; checks != nil && len(checks.Contexts) == 0 {
+		return fmt.Errorf("required status checks must list at least one context")
+	}
+	for _, actor := range ruleset.BypassActors {
+		if !slices.Contains(bypassActorTypes, actor.ActorType) {
+			return fmt.Errorf("unknown bypass actor type %q", actor.ActorType)
+		}
+		if actor.BypassMode != "" && !slices.Contains(bypassModes, actor.BypassMode) {
+			return fmt.Errorf("unknown bypass mode %q", actor.BypassMode)
+		}
+	}
+	return nil
+}
+
+// requiresLinearHistory reports whether the protection of the default branch
+// rejects merge commits.
+func requiresLinearHistory(mode ProtectionMode, ruleset *RulesetArgs) bool {
+	if mode != ProtectionRuleset || ruleset == nil || ruleset.RequiredLinearHistory == nil {
+		return true
+	}
+	return *ruleset.RequiredLinearHistory
+}
+
+// blocksDirectPushes reports whether the ruleset requires pull requests on the
+// given default branch without an actor that can always bypass it, so that
+// commits cannot be pushed to the branch directly.
+func blocksDirectPushes(mode ProtectionMode, ruleset *RulesetArgs, branch string) bool {
+	if mode != ProtectionRuleset || ruleset == nil || ruleset.RequiredReviews == nil {
+		return false
+	}
+	if ruleset.Enforcement != "" && ruleset.Enforcement != "active" {
+		return false
+	}
+	include := ruleset.Include
+	if len(include) == 0 {
+		include = []string{defaultBranchRef}
+	}
+	if !slices.ContainsFunc(include, func(pattern string) bool {
+		return pattern == defaultBranchRef || pattern == "~ALL" || pattern == "refs/heads/"+branch
+	}) {
+		return false
+	}
+	return !slices.ContainsFunc(ruleset.BypassActors, func(actor BypassActor) bool {
+		return actor.BypassMode == "" || actor.BypassMode == "always"
+	})
+}
+
+// rulesetArgs translates the ruleset arguments into the arguments of a
+// github.RepositoryRuleset. A nil ruleset yields the defaults, which mirror
+// the classic branch protection of the main branch.
+func rulesetArgs(repository pulumi.StringInput, ruleset *RulesetArgs) *github.RepositoryRulesetArgs {
+	if ruleset == nil {
+		ruleset = &RulesetArgs{}
+	}
+
+	name := ruleset.Name
+	if name == "" {
+		name = "default-branch"
+	}
+	enforcement := ruleset.Enforcement
+	if enforcement == "" {
+		enforcement = "active"
+	}
+	include := ruleset.Include
+	if len(include) == 0 {
+		include = []string{defaultBranchRef}
+	}
+	linearHistory := requiresLinearHistory(ProtectionRuleset, ruleset)
+
+	rules := &github.RepositoryRulesetRulesArgs{
+		Deletion:              pulumi.Bool(true),
+		NonFastForward:        pulumi.Bool(true),
+		RequiredLinearHistory: pulumi.Bool(linearHistory),
+		RequiredSignatures:    pulumi.Bool(ruleset.RequiredSignatures),
+	}
+	if reviews := ruleset.RequiredReviews; reviews != nil {
+		rules.PullRequest = &github.RepositoryRulesetRulesPullRequestArgs{
+			RequiredApprovingReviewCount:   pulumi.Int(reviews.ApprovingReviewCount),
+			DismissStaleReviewsOnPush:      pulumi.Bool(reviews.DismissStaleReviewsOnPush),
+			RequireCodeOwnerReview:         pulumi.Bool(reviews.RequireCodeOwnerReview),
+			RequireLastPushApproval:        pulumi.Bool(reviews.RequireLastPushApproval),
+			RequiredReviewThreadResolution: pulumi.Bool(reviews.RequireThreadResolution),
+		}
+	}
+	if checks := ruleset.RequiredStatusChecks; checks != nil {
+		requiredChecks := github.RepositoryRulesetRulesRequiredStatusChecksRequiredCheckArray{}
+		for _, context := range checks.Contexts {
+			requiredChecks = append(requiredChecks, &github.RepositoryRulesetRulesRequiredStatusChecksRequiredCheckArgs{
+				Context: pulumi.String(context),
+			})
+		}
+		rules.RequiredStatusChecks = &github.RepositoryRulesetRulesRequiredStatusChecksArgs{
+			RequiredChecks:                   requiredChecks,
+			StrictRequiredStatusChecksPolicy: pulumi.Bool(checks.Strict),
+		}
+	}
+
+	bypassActors := github.RepositoryRulesetBypassActorArray{}
+	for _, actor := range ruleset.BypassActors {
+		mode := actor.BypassMode
+		if mode == "" {
+			mode = "always"
+		}
+		bypassActors = append(bypassActors, &github.RepositoryRulesetBypassActorArgs{
+			ActorId:    pulumi.Int(actor.ActorID),
+			ActorType:  pulumi.String(actor.ActorType),
+			BypassMode: pulumi.String(mode),
+		})
+	}
+
+	return &github.RepositoryRulesetArgs{
+		Repository:   repository,
+		Name:         pulumi.String(name),
+		Target:       pulumi.String("branch"),
+		Enforcement:  pulumi.String(enforcement),
+		BypassActors: bypassActors,
+		Conditions: &github.RepositoryRulesetConditionsArgs{
+			RefName: &github.RepositoryRulesetConditionsRefNameArgs{
+				Includes: pulumi.ToStringArray(include),
+				Excludes: pulumi.ToStringArray(ruleset.Exclude),
+			},
+		},
+		Rules: rules,
+	}
+}
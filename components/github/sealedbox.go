@@ -0,0 +1,49 @@
+package github
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+// SealSecret encrypts plaintext for the base64-encoded Curve25519 public key
+// of a repository, as expected by the GitHub Actions secrets API. The result
+// is a libsodium-compatible sealed box (crypto_box_seal), encoded in base64.
+func SealSecret(publicKey, plaintext string) (string, error) {
+	return sealSecret(publicKey, plaintext, rand.Reader)
+}
+
+// sealSecretStable works like SealSecret, but derives the ephemeral key from
+// the public key and the plaintext. The ciphertext is therefore stable for a
+// given key and value, so that an unchanged secret does not show up as a diff
+// on every update. The flip side is that a guessed value can be confirmed
+// against the ciphertext, so it is only suitable for high-entropy secrets
+// such as tokens.
+func sealSecretStable(publicKey, plaintext string) (string, error) {
+	seed := sha256.Sum256([]byte("pulumi-go-components sealed secret\x00" + publicKey + "\x00" + plaintext))
+	return sealSecret(publicKey, plaintext, bytes.NewReader(seed[:]))
+}
+
+// sealSecret encrypts plaintext into a sealed box, reading the ephemeral key from rand.
+func sealSecret(publicKey, plaintext string, rand io.Reader) (string, error) {
+	key, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode public key: %w", err)
+	}
+	if len(key) != 32 {
+		return "", fmt.Errorf("public key must be 32 bytes, got %d", len(key))
+	}
+
+	var recipient [32]byte
+	copy(recipient[:], key)
+	sealed, err := box.SealAnonymous(nil, []byte(plaintext), &recipient, rand)
+	if err != nil {
+		return "", fmt.Errorf("failed to seal secret: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
@@ -0,0 +1,93 @@
+package github
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// The test vectors use the recipient key pair below. The private key is the
+// byte sequence 0x01..0x20, the public key is derived from it with X25519.
+const (
+	testPrivateKey = "AQIDBAUGBwgJCgsMDQ4PEBESExQVFhcYGRobHB0eHyA="
+	testPublicKey  = "B6N8vBQgk8i3VdwbEOhstCY3StFqqFPtC9/AsrhtHHw="
+)
+
+// openSealed opens a base64-encoded sealed box with the test key pair.
+func openSealed(t *testing.T, sealed string) (string, bool) {
+	t.Helper()
+	var publicKey, privateKey [32]byte
+	copy(publicKey[:], mustDecode(t, testPublicKey))
+	copy(privateKey[:], mustDecode(t, testPrivateKey))
+	message, ok := box.OpenAnonymous(nil, mustDecode(t, sealed), &publicKey, &privateKey)
+	return string(message), ok
+}
+
+func mustDecode(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := base64.StdEncoding.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func TestSealSecret_KnownAnswer(t *testing.T) {
+	// The ephemeral key is the byte sequence 0x21..0x40. The expected sealed
+	// box opens with libsodium's crypto_box_seal_open.
+	ephemeral := make([]byte, 32)
+	for i := range ephemeral {
+		ephemeral[i] = byte(0x21 + i)
+	}
+
+	sealed, err := sealSecret(testPublicKey, "correct horse battery staple", bytes.NewReader(ephemeral))
+	require.NoError(t, err)
+	assert.Equal(t, "WGmv9FBUlzLLqu1eXfmzCm2jHLDldCutWtShp2jxpnvUP7Nxs5/scadagoZ5hGjIIbfseeU9OFcAu4ieSe2mKiFR80yTNFWxptS4wg==", sealed)
+}
+
+func TestSealSecret_OpensLibsodiumVector(t *testing.T) {
+	// Sealed with libsodium's crypto_box_seal for the test public key.
+	message, ok := openSealed(t, "3tG05lCrPvA/ERWDr8KKxXdIpKSVjzz6RI44CIyysFon9IlrF5rCzhct4k3ReAUvuLbJ1sMrxclTxBhDj0tGYG2YqA==")
+	assert.True(t, ok)
+	assert.Equal(t, "sealed by libsodium", message)
+}
+
+func TestSealSecret_RoundTrip(t *testing.T) {
+	sealed, err := SealSecret(testPublicKey, "glpat-secret-token")
+	require.NoError(t, err)
+
+	message, ok := openSealed(t, sealed)
+	assert.True(t, ok)
+	assert.Equal(t, "glpat-secret-token", message)
+
+	again, err := SealSecret(testPublicKey, "glpat-secret-token")
+	require.NoError(t, err)
+	assert.NotEqual(t, sealed, again, "SealSecret should use a fresh ephemeral key for every call")
+}
+
+func TestSealSecretStable(t *testing.T) {
+	sealed, err := sealSecretStable(testPublicKey, "glpat-secret-token")
+	require.NoError(t, err)
+
+	message, ok := openSealed(t, sealed)
+	assert.True(t, ok)
+	assert.Equal(t, "glpat-secret-token", message)
+
+	again, err := sealSecretStable(testPublicKey, "glpat-secret-token")
+	require.NoError(t, err)
+	assert.Equal(t, sealed, again, "the ciphertext should be stable for a given key and value")
+
+	other, err := sealSecretStable(testPublicKey, "glpat-other-token")
+	require.NoError(t, err)
+	assert.NotEqual(t, sealed[:44], other[:44], "different values should use different ephemeral keys")
+}
+
+func TestSealSecret_InvalidPublicKey(t *testing.T) {
+	_, err := SealSecret("not base64!", "value")
+	assert.ErrorContains(t, err, "failed to decode public key")
+
+	_, err = SealSecret(base64.StdEncoding.EncodeToString([]byte("short")), "value")
+	assert.EqualError(t, err, "public key must be 32 bytes, got 5")
+}
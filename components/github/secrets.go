@@ -0,0 +1,114 @@
+package github
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// legacySecretSuffixes keeps the child name suffix that the GitLab secrets had
+// before they were derived from the secret name, so that existing stacks are
+// not replaced.
+var legacySecretSuffixes = map[string]string{
+	"GITLAB_REPOSITORY": "gitlab-repo",
+	"GITLAB_TOKEN":      "gitlab-token",
+	"GITLAB_OWNER":      "gitlab-owner",
+}
+
+// GitLabMirrorSecrets returns the Actions secrets used by workflows that mirror
+// the repository to GitLab. The result can be passed as StandardRepoArgs.Secrets
+// or merged into a larger secret map with maps.Copy.
+func GitLabMirrorSecrets(repository, token, owner pulumi.StringInput) map[string]pulumi.StringInput {
+	return map[string]pulumi.StringInput{
+		"GITLAB_REPOSITORY": repository,
+		"GITLAB_TOKEN":      token,
+		"GITLAB_OWNER":      owner,
+	}
+}
+
+// validateSecrets checks that every secret has a value and a name that follows
+// GitHub's rules. Names are compared case-insensitively, as on GitHub.
+func validateSecrets(secrets map[string]pulumi.StringInput) error {
+	seen := make(map[string]string)
+	for _, secretName := range slices.Sorted(maps.Keys(secrets)) {
+		if err := validateActionsName("secret", secretName); err != nil {
+			return err
+		}
+		key := strings.ToUpper(secretName)
+		if other, ok := seen[key]; ok {
+			return fmt.Errorf("secrets %q and %q differ only in case", other, secretName)
+		}
+		seen[key] = secretName
+		if secrets[secretName] == nil {
+			return fmt.Errorf("secret %q has no value", secretName)
+		}
+	}
+	return nil
+}
+
+// validateStableSealedSecrets checks that every secret sealed
+// deterministically is declared and encrypted.
+func validateStableSealedSecrets(names []string, secrets map[string]pulumi.StringInput, encrypt bool) error {
+	if len(names) > 0 && !encrypt {
+		return fmt.Errorf("StableSealedSecrets requires EncryptSecrets")
+	}
+	for _, secretName := range names {
+		if _, ok := secrets[secretName]; !ok {
+			return fmt.Errorf("stable sealed secret %q is not declared in Secrets", secretName)
+		}
+	}
+	return nil
+}
+
+// newSecrets creates an Actions secret for every entry of the map and returns
+// them keyed by secret name. The values are marked as Pulumi secrets, so they
+// are encrypted in the state. When a public key is given, the values are
+// sealed against it client-side and only the encrypted values are passed to
+// the provider. The secrets listed as stable are sealed deterministically.
+func newSecrets(ctx *pulumi.Context, name string, repository pulumi.StringInput, secrets map[string]pulumi.StringInput, publicKey pulumi.StringInput, stable []string, legacyNames bool, opts ...pulumi.ResourceOption) (map[string]*github.ActionsSecret, error) {
+	created := make(map[string]*github.ActionsSecret)
+	for _, secretName := range slices.Sorted(maps.Keys(secrets)) {
+		suffix := "secret-" + secretSuffix(secretName)
+		_, legacy := legacySecretSuffixes[secretName]
+		secretOpts := append(slices.Clone(opts), legacyAlias(legacyNames && legacy, suffix))
+		secretArgs := &github.ActionsSecretArgs{
+			Repository: repository,
+			SecretName: pulumi.String(secretName),
+		}
+		if publicKey != nil {
+			secretArgs.EncryptedValue = sealedValue(publicKey, secrets[secretName], slices.Contains(stable, secretName))
+		} else {
+			secretArgs.PlaintextValue = pulumi.ToSecret(secrets[secretName]).(pulumi.StringOutput)
+		}
+		secret, err := github.NewActionsSecret(ctx, childName(name, suffix), secretArgs, secretOpts...)
+		if err != nil {
+			return nil, err
+		}
+		created[secretName] = secret
+	}
+	return created, nil
+}
+
+// sealedValue seals the value against the public key as a Pulumi secret,
+// deterministically when stable is set.
+func sealedValue(publicKey, value pulumi.StringInput, stable bool) pulumi.StringOutput {
+	sealed := pulumi.All(publicKey, value).ApplyT(func(args []any) (string, error) {
+		if stable {
+			return sealSecretStable(args[0].(string), args[1].(string))
+		}
+		return SealSecret(args[0].(string), args[1].(string))
+	})
+	return pulumi.ToSecret(sealed).(pulumi.StringOutput)
+}
+
+// secretSuffix derives the child name suffix of a secret from its name.
+func secretSuffix(secretName string) string {
+	if suffix, ok := legacySecretSuffixes[secretName]; ok {
+		return suffix
+	}
+	return actionsSuffix(secretName)
+}
@@ -0,0 +1,157 @@
+package github_test
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/softwaredevelop/pulumi-go-components/components/github"
+)
+
+func TestNewStandardRepo_Secrets(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			Secrets: map[string]pulumi.StringInput{
+				"NPM_TOKEN":   pulumi.String("npm-token-value"),
+				"SLACK_TOKEN": pulumi.String("slack-token-value"),
+			},
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"repo-secret-npm-token", "repo-secret-slack-token"}, mocks.names("github:index/actionsSecret:ActionsSecret"))
+	inputs := mocks.inputs("repo-secret-npm-token")
+	assert.Equal(t, "NPM_TOKEN", inputs["secretName"].StringValue())
+	assert.True(t, inputs["plaintextValue"].IsSecret(), "the secret value must stay a Pulumi secret")
+	assert.Equal(t, "npm-token-value", inputs["plaintextValue"].SecretValue().Element.StringValue())
+}
+
+func TestNewStandardRepo_NoSecretsByDefault(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	assert.Empty(t, mocks.names("github:index/actionsSecret:ActionsSecret"))
+}
+
+func TestNewStandardRepo_GitLabMirrorSecrets(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			Secrets: github.GitLabMirrorSecrets(
+				pulumi.String("gitlab.com/group/test-repo"),
+				pulumi.String("glpat-token"),
+				pulumi.String("group"),
+			),
+			LegacyChildNames: true,
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"repo-secret-gitlab-owner", "repo-secret-gitlab-repo", "repo-secret-gitlab-token"},
+		mocks.names("github:index/actionsSecret:ActionsSecret"))
+	// With LegacyChildNames, the former fixed names are kept as aliases so that an existing stack migrates in place.
+	assert.Equal(t, []string{"secret-gitlab-repo"}, mocks.aliasNames("repo-secret-gitlab-repo"))
+	assert.Equal(t, []string{"secret-gitlab-token"}, mocks.aliasNames("repo-secret-gitlab-token"))
+	assert.Equal(t, []string{"secret-gitlab-owner"}, mocks.aliasNames("repo-secret-gitlab-owner"))
+}
+
+func TestNewStandardRepo_SecretWithoutValue(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			Secrets:        map[string]pulumi.StringInput{"NPM_TOKEN": nil},
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", standardRepoMocks(0)))
+	assert.ErrorContains(t, err, `secret "NPM_TOKEN" has no value`)
+}
+
+func TestNewStandardRepo_EncryptSecrets(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			Secrets:        map[string]pulumi.StringInput{"NPM_TOKEN": pulumi.String("npm-token-value")},
+			EncryptSecrets: true,
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	inputs := mocks.inputs("repo-secret-npm-token")
+	assert.NotContains(t, inputs, resource.PropertyKey("plaintextValue"), "the plaintext value must not reach the provider")
+	encrypted := inputs["encryptedValue"]
+	assert.True(t, encrypted.IsSecret(), "the encrypted value must stay a Pulumi secret")
+	assert.NotContains(t, encrypted.SecretValue().Element.StringValue(), "npm-token-value")
+}
+
+func TestNewStandardRepo_StableSealedSecrets(t *testing.T) {
+	// sealed runs the program twice and returns the ciphertext of each secret per run.
+	sealed := func(t *testing.T) map[string][]string {
+		values := make(map[string][]string)
+		for range 2 {
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName: pulumi.String("test-repo"),
+					Secrets: map[string]pulumi.StringInput{
+						"NPM_TOKEN":    pulumi.String("npm-token-value"),
+						"GITLAB_OWNER": pulumi.String("owner"),
+					},
+					EncryptSecrets:      true,
+					StableSealedSecrets: []string{"NPM_TOKEN"},
+				})
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			require.NoError(t, err)
+			for _, name := range []string{"repo-secret-npm-token", "repo-secret-gitlab-owner"} {
+				values[name] = append(values[name], mocks.inputs(name)["encryptedValue"].SecretValue().Element.StringValue())
+			}
+		}
+		return values
+	}(t)
+
+	// Only the secrets that opt in are sealed deterministically.
+	assert.Equal(t, sealed["repo-secret-npm-token"][0], sealed["repo-secret-npm-token"][1])
+	assert.NotEqual(t, sealed["repo-secret-gitlab-owner"][0], sealed["repo-secret-gitlab-owner"][1])
+}
+
+func TestNewStandardRepo_InvalidStableSealedSecrets(t *testing.T) {
+	tests := []struct {
+		name        string
+		encrypt     bool
+		stable      []string
+		expectedMsg string
+	}{
+		{"WithoutEncryption", false, []string{"NPM_TOKEN"}, "StableSealedSecrets requires EncryptSecrets"},
+		{"Undeclared", true, []string{"PYPI_TOKEN"}, `stable sealed secret "PYPI_TOKEN" is not declared in Secrets`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName:      pulumi.String("test-repo"),
+					Secrets:             map[string]pulumi.StringInput{"NPM_TOKEN": pulumi.String("token")},
+					EncryptSecrets:      tt.encrypt,
+					StableSealedSecrets: tt.stable,
+				})
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", standardRepoMocks(0)))
+			assert.ErrorContains(t, err, tt.expectedMsg)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package github
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// SecuritySettings holds the security and analysis settings of the repository.
+// They are managed when a profile or an override is given. Nil fields then
+// take the default of the repository's visibility: every feature is enabled
+// on public repositories, while private and internal repositories leave
+// secret scanning and push protection off unless advanced security is
+// enabled, as they require it there. Dependent features follow the feature
+// they depend on.
+//
+// Private vulnerability reporting is not covered, as the GitHub provider has
+// no resource for it.
+type SecuritySettings struct {
+	// Enable GitHub Advanced Security. Only applies to private and internal
+	// repositories, where it is required by secret scanning.
+	AdvancedSecurity *bool
+	// Scan the repository for committed secrets.
+	SecretScanning *bool
+	// Block pushes that contain secrets. Requires secret scanning.
+	SecretScanningPushProtection *bool
+	// Alert on dependencies with known vulnerabilities.
+	VulnerabilityAlerts *bool
+	// Open pull requests that update vulnerable dependencies. Requires vulnerability alerts.
+	DependabotSecurityUpdates *bool
+}
+
+// resolveSecurity applies the non-nil fields of the override on top of the
+// defaults of the visibility, and checks that the settings are consistent.
+// Unless managed, a nil override leaves every setting nil, except for the
+// vulnerability alerts and security updates that Dependabot relies on.
+func resolveSecurity(visibility string, managed bool, override *SecuritySettings, dependabot bool) (SecuritySettings, error) {
+	if !managed && override == nil {
+		if dependabot {
+			return SecuritySettings{VulnerabilityAlerts: ptr(true), DependabotSecurityUpdates: ptr(true)}, nil
+		}
+		return SecuritySettings{}, nil
+	}
+	public := visibility == "public"
+	if override == nil {
+		override = &SecuritySettings{}
+	}
+	// Dependent features follow the feature they depend on unless set.
+	security := SecuritySettings{AdvancedSecurity: override.AdvancedSecurity}
+	advanced := security.AdvancedSecurity != nil && *security.AdvancedSecurity
+	security.SecretScanning = ptr(public || advanced)
+	overrideField(&security.SecretScanning, override.SecretScanning)
+	security.SecretScanningPushProtection = ptr(*security.SecretScanning)
+	overrideField(&security.SecretScanningPushProtection, override.SecretScanningPushProtection)
+	security.VulnerabilityAlerts = ptr(true)
+	overrideField(&security.VulnerabilityAlerts, override.VulnerabilityAlerts)
+	security.DependabotSecurityUpdates = ptr(*security.VulnerabilityAlerts)
+	overrideField(&security.DependabotSecurityUpdates, override.DependabotSecurityUpdates)
+
+	if public && security.AdvancedSecurity != nil {
+		return SecuritySettings{}, fmt.Errorf("advanced security is always enabled on public repositories and cannot be set")
+	}
+	if !public && *security.SecretScanning && !advanced {
+		return SecuritySettings{}, fmt.Errorf("secret scanning on a %s repository requires advanced security", visibility)
+	}
+	if *security.SecretScanningPushProtection && !*security.SecretScanning {
+		return SecuritySettings{}, fmt.Errorf("secret scanning push protection requires secret scanning")
+	}
+	if *security.DependabotSecurityUpdates && !*security.VulnerabilityAlerts {
+		return SecuritySettings{}, fmt.Errorf("dependabot security updates require vulnerability alerts")
+	}
+	return security, nil
+}
+
+// securityAndAnalysisArgs converts the settings into the security and analysis
+// configuration of the repository. Unmanaged settings, and private and
+// internal repositories without advanced security, leave it unset, as GitHub
+// rejects it there.
+func securityAndAnalysisArgs(visibility string, security SecuritySettings) github.RepositorySecurityAndAnalysisPtrInput {
+	if security.SecretScanning == nil || visibility != "public" && security.AdvancedSecurity == nil {
+		return nil
+	}
+	args := &github.RepositorySecurityAndAnalysisArgs{
+		SecretScanning: &github.RepositorySecurityAndAnalysisSecretScanningArgs{
+			Status: status(*security.SecretScanning),
+		},
+		SecretScanningPushProtection: &github.RepositorySecurityAndAnalysisSecretScanningPushProtectionArgs{
+			Status: status(*security.SecretScanningPushProtection),
+		},
+	}
+	if security.AdvancedSecurity != nil {
+		args.AdvancedSecurity = &github.RepositorySecurityAndAnalysisAdvancedSecurityArgs{
+			Status: status(*security.AdvancedSecurity),
+		}
+	}
+	return args
+}
+
+// newDependabotSecurityUpdates enables or disables Dependabot security updates.
+// They depend on the vulnerability alerts, which are set on the repository.
+func newDependabotSecurityUpdates(ctx *pulumi.Context, name string, repository pulumi.StringInput, enabled bool, opts ...pulumi.ResourceOption) error {
+	_, err := github.NewRepositoryDependabotSecurityUpdates(ctx, childName(name, "dependabot-security-updates"), &github.RepositoryDependabotSecurityUpdatesArgs{
+		Repository: repository,
+		Enabled:    pulumi.Bool(enabled),
+	}, opts...)
+	return err
+}
+
+// status converts a setting into the status GitHub expects.
+func status(enabled bool) pulumi.String {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
@@ -0,0 +1,168 @@
+package github_test
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/softwaredevelop/pulumi-go-components/components/github"
+)
+
+func TestNewStandardRepo_Security(t *testing.T) {
+	tests := []struct {
+		name                      string
+		profile                   github.RepositoryProfile
+		security                  *github.SecuritySettings
+		advancedSecurity          string
+		secretScanning            string
+		pushProtection            string
+		vulnerabilityAlerts       bool
+		dependabotSecurityUpdates bool
+	}{
+		{
+			name:                      "PublicDefaults",
+			profile:                   github.ProfileOpenSource,
+			secretScanning:            "enabled",
+			pushProtection:            "enabled",
+			vulnerabilityAlerts:       true,
+			dependabotSecurityUpdates: true,
+		},
+		{
+			name:                      "PrivateDefaults",
+			profile:                   github.ProfilePrivateConfidential,
+			vulnerabilityAlerts:       true,
+			dependabotSecurityUpdates: true,
+		},
+		{
+			name:                      "PrivateWithAdvancedSecurity",
+			profile:                   github.ProfilePrivateConfidential,
+			security:                  &github.SecuritySettings{AdvancedSecurity: ptr(true)},
+			advancedSecurity:          "enabled",
+			secretScanning:            "enabled",
+			pushProtection:            "enabled",
+			vulnerabilityAlerts:       true,
+			dependabotSecurityUpdates: true,
+		},
+		{
+			name:                      "InternalWithoutPushProtection",
+			profile:                   github.ProfileInternal,
+			security:                  &github.SecuritySettings{AdvancedSecurity: ptr(true), SecretScanningPushProtection: ptr(false)},
+			advancedSecurity:          "enabled",
+			secretScanning:            "enabled",
+			pushProtection:            "disabled",
+			vulnerabilityAlerts:       true,
+			dependabotSecurityUpdates: true,
+		},
+		{
+			name:           "PublicWithoutAlerts",
+			profile:        github.ProfileOpenSource,
+			security:       &github.SecuritySettings{SecretScanning: ptr(false), VulnerabilityAlerts: ptr(false)},
+			secretScanning: "disabled",
+			pushProtection: "disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName: pulumi.String("test-repo"),
+					Profile:        tt.profile,
+					Security:       tt.security,
+				})
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			assert.NoError(t, err)
+
+			repository := mocks.inputs("repo-repository")
+			assert.Equal(t, tt.vulnerabilityAlerts, repository["vulnerabilityAlerts"].BoolValue())
+			assert.Equal(t, tt.dependabotSecurityUpdates, mocks.inputs("repo-dependabot-security-updates")["enabled"].BoolValue())
+
+			if tt.secretScanning == "" {
+				assert.NotContains(t, repository, "securityAndAnalysis")
+				return
+			}
+			securityAndAnalysis := repository["securityAndAnalysis"].ObjectValue()
+			assert.Equal(t, tt.secretScanning, status(securityAndAnalysis, "secretScanning"))
+			assert.Equal(t, tt.pushProtection, status(securityAndAnalysis, "secretScanningPushProtection"))
+			assert.Equal(t, tt.advancedSecurity, status(securityAndAnalysis, "advancedSecurity"))
+		})
+	}
+}
+
+func TestNewStandardRepo_UnmanagedSecurity(t *testing.T) {
+	tests := []struct {
+		name                string
+		dependabot          []github.DependabotUpdate
+		vulnerabilityAlerts bool
+	}{
+		{"WithoutDependabot", nil, false},
+		// Dependabot still needs vulnerability alerts and security updates.
+		{"WithDependabot", []github.DependabotUpdate{{Ecosystem: "gomod"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName: pulumi.String("test-repo"),
+					Dependabot:     tt.dependabot,
+				})
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			assert.NoError(t, err)
+
+			repository := mocks.inputs("repo-repository")
+			assert.NotContains(t, repository, resource.PropertyKey("securityAndAnalysis"))
+			if !tt.vulnerabilityAlerts {
+				assert.NotContains(t, repository, resource.PropertyKey("vulnerabilityAlerts"))
+				assert.Empty(t, mocks.names("github:index/repositoryDependabotSecurityUpdates:RepositoryDependabotSecurityUpdates"))
+				return
+			}
+			assert.True(t, repository["vulnerabilityAlerts"].BoolValue())
+			assert.True(t, mocks.inputs("repo-dependabot-security-updates")["enabled"].BoolValue())
+		})
+	}
+}
+
+func TestNewStandardRepo_InvalidSecurity(t *testing.T) {
+	tests := []struct {
+		name        string
+		profile     github.RepositoryProfile
+		security    *github.SecuritySettings
+		expectedMsg string
+	}{
+		{"AdvancedSecurityOnPublic", github.ProfileOpenSource, &github.SecuritySettings{AdvancedSecurity: ptr(true)}, "advanced security is always enabled on public repositories"},
+		{"SecretScanningWithoutAdvancedSecurity", github.ProfilePrivateConfidential, &github.SecuritySettings{SecretScanning: ptr(true)}, `secret scanning on a private repository requires advanced security`},
+		{"PushProtectionWithoutSecretScanning", github.ProfileOpenSource, &github.SecuritySettings{SecretScanning: ptr(false), SecretScanningPushProtection: ptr(true)}, "push protection requires secret scanning"},
+		{"SecurityUpdatesWithoutAlerts", github.ProfileOpenSource, &github.SecuritySettings{VulnerabilityAlerts: ptr(false), DependabotSecurityUpdates: ptr(true)}, "dependabot security updates require vulnerability alerts"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName: pulumi.String("test-repo"),
+					Profile:        tt.profile,
+					Security:       tt.security,
+				})
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			assert.ErrorContains(t, err, tt.expectedMsg)
+			assert.Empty(t, mocks.resources, "nothing should be registered for invalid security settings")
+		})
+	}
+}
+
+// status returns the status of a security and analysis feature, or "" if it is not set.
+func status(securityAndAnalysis resource.PropertyMap, feature resource.PropertyKey) string {
+	if !securityAndAnalysis[feature].IsObject() {
+		return ""
+	}
+	return securityAndAnalysis[feature].ObjectValue()["status"].StringValue()
+}
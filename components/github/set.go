@@ -0,0 +1,198 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// StandardRepoSetType is the type token of the StandardRepoSet component.
+const StandardRepoSetType = "softwaredevelop:github/v1:StandardRepoSet"
+
+// RepositorySpec declares a repository of a StandardRepoSet together with
+// the settings where it differs from the defaults of the set.
+type RepositorySpec struct {
+	// The name of the repository on GitHub. It also names the StandardRepo
+	// of the repository, so it must be unique within the set.
+	Name string
+	// The description of the repository. Defaults to that of the set.
+	Description pulumi.StringInput
+	// The topics of the repository. They replace the topics of the set.
+	Topics pulumi.StringArrayInput
+	// The policy profile of the repository. Defaults to that of the set.
+	Profile RepositoryProfile
+	// Overrides individual settings of the policy of the set.
+	Policy *RepositoryPolicy
+	// Additional issue labels. They replace labels of the set with the same name.
+	Labels []Label
+	// Additional Actions secrets and variables, keyed by name. They replace
+	// entries of the set with the same name.
+	Secrets   map[string]pulumi.StringInput
+	Variables map[string]pulumi.StringInput
+	// Additional teams and collaborators. They replace the permission that
+	// the set grants to the same team or user.
+	Teams         map[string]Permission
+	Collaborators map[string]Permission
+	// What happens to the repository when it is removed from the set.
+	// Defaults to the lifecycle of the set.
+	Lifecycle Lifecycle
+	// Adopt the existing repository instead of creating it. Defaults to
+	// Adopt of the set.
+	Adopt *bool
+}
+
+// StandardRepoSetArgs holds the arguments of a StandardRepoSet.
+type StandardRepoSetArgs struct {
+	// The settings shared by every repository. RepositoryName is ignored, as
+	// each repository takes its name from its spec. LegacyChildNames is
+	// rejected, as the fixed names would collide between the repositories.
+	Defaults StandardRepoArgs
+	// The repositories of the set.
+	Repositories []RepositorySpec
+}
+
+// StandardRepoSet manages a fleet of repositories that share a baseline,
+// creating a StandardRepo for every repository of the set.
+type StandardRepoSet struct {
+	pulumi.ResourceState
+
+	// The URLs of the repositories, keyed by repository name.
+	RepositoryURLs pulumi.StringMapOutput `pulumi:"repositoryUrls"`
+	// The components of the repositories, keyed by repository name.
+	Repositories map[string]*StandardRepo
+}
+
+// NewStandardRepoSet creates a StandardRepo for every repository of the set.
+// Every repository is validated before anything is registered, and all
+// problems, such as duplicate names, are reported together in one error.
+func NewStandardRepoSet(ctx *pulumi.Context, name string, args *StandardRepoSetArgs, opts ...pulumi.ResourceOption) (*StandardRepoSet, error) {
+	repoArgs := make([]*StandardRepoArgs, len(args.Repositories))
+	plans := make([]*standardRepoPlan, len(args.Repositories))
+	var errs []error
+	if args.Defaults.LegacyChildNames {
+		errs = append(errs, errors.New("LegacyChildNames does not apply to repository sets, "+
+			"as the fixed child names would collide between repositories"))
+	}
+	seen := make(map[string]bool)
+	for i, spec := range args.Repositories {
+		// Repository names are case-insensitive on GitHub.
+		key := strings.ToLower(spec.Name)
+		if seen[key] {
+			errs = append(errs, fmt.Errorf("repository %q is declared more than once", spec.Name))
+			continue
+		}
+		seen[key] = true
+		repoArgs[i] = spec.args(&args.Defaults)
+		plan, err := resolveStandardRepo(repoArgs[i])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("repository %q: %w", spec.Name, err))
+			continue
+		}
+		plans[i] = plan
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	standardRepoSet := &StandardRepoSet{Repositories: make(map[string]*StandardRepo)}
+	err := ctx.RegisterComponentResource(StandardRepoSetType, name, standardRepoSet, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := pulumi.StringMap{}
+	for i, spec := range args.Repositories {
+		standardRepo, err := newStandardRepo(ctx, childName(name, spec.Name), repoArgs[i], plans[i],
+			pulumi.Parent(standardRepoSet))
+		if err != nil {
+			return nil, err
+		}
+		standardRepoSet.Repositories[spec.Name] = standardRepo
+		urls[spec.Name] = standardRepo.RepositoryURL
+	}
+	standardRepoSet.RepositoryURLs = urls.ToStringMapOutput()
+
+	if err := ctx.RegisterResourceOutputs(standardRepoSet, pulumi.Map{
+		"repositoryUrls": standardRepoSet.RepositoryURLs,
+	}); err != nil {
+		return nil, err
+	}
+
+	return standardRepoSet, nil
+}
+
+// args applies the spec on top of the defaults of the set.
+func (spec *RepositorySpec) args(defaults *StandardRepoArgs) *StandardRepoArgs {
+	args := *defaults
+	args.RepositoryName = pulumi.String(spec.Name)
+	if spec.Description != nil {
+		args.Description = spec.Description
+	}
+	if spec.Topics != nil {
+		args.Topics = spec.Topics
+	}
+	if spec.Profile != "" {
+		args.Profile = spec.Profile
+	}
+	args.Policy = overridePolicy(defaults.Policy, spec.Policy)
+	args.Labels = overrideLabels(defaults.Labels, spec.Labels)
+	args.Secrets = overrideEntries(defaults.Secrets, spec.Secrets)
+	args.Variables = overrideEntries(defaults.Variables, spec.Variables)
+	args.Teams = overrideEntries(defaults.Teams, spec.Teams)
+	args.Collaborators = overrideEntries(defaults.Collaborators, spec.Collaborators)
+	if spec.Lifecycle != "" {
+		args.Lifecycle = spec.Lifecycle
+	}
+	if spec.Adopt != nil {
+		args.Adopt = *spec.Adopt
+	}
+	return &args
+}
+
+// overridePolicy applies the non-nil fields of the override on top of the
+// policy of the set.
+func overridePolicy(policy, override *RepositoryPolicy) *RepositoryPolicy {
+	if override == nil {
+		return policy
+	}
+	if policy == nil {
+		return override
+	}
+	merged := *policy
+	merged.apply(override)
+	return &merged
+}
+
+// overrideLabels appends the labels of the override to those of the set,
+// dropping the labels of the set that the override redeclares.
+func overrideLabels(labels, override []Label) []Label {
+	if len(override) == 0 {
+		return labels
+	}
+	redeclared := make(map[string]bool)
+	for _, label := range override {
+		redeclared[strings.ToLower(label.Name)] = true
+	}
+	var merged []Label
+	for _, label := range labels {
+		if !redeclared[strings.ToLower(label.Name)] {
+			merged = append(merged, label)
+		}
+	}
+	return append(merged, override...)
+}
+
+// overrideEntries returns the entries of the set with those of the override
+// added or replaced, leaving both maps unchanged.
+func overrideEntries[V any](entries, override map[string]V) map[string]V {
+	if len(override) == 0 {
+		return entries
+	}
+	merged := make(map[string]V, len(entries)+len(override))
+	maps.Copy(merged, entries)
+	maps.Copy(merged, override)
+	return merged
+}
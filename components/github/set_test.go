@@ -0,0 +1,138 @@
+package github_test
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/softwaredevelop/pulumi-go-components/components/github"
+)
+
+func TestNewStandardRepoSet(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		set, err := github.NewStandardRepoSet(ctx, "fleet", &github.StandardRepoSetArgs{
+			Defaults: github.StandardRepoArgs{
+				Description: pulumi.String("A fleet repository"),
+				Topics:      pulumi.ToStringArray([]string{"pulumi"}),
+				Profile:     github.ProfileOpenSource,
+				Policy:      &github.RepositoryPolicy{HasDiscussions: ptr(false)},
+				Labels:      []github.Label{{Name: "bug", Color: "D73A4A"}},
+				Secrets:     map[string]pulumi.StringInput{"NPM_TOKEN": pulumi.String("token")},
+			},
+			Repositories: []github.RepositorySpec{
+				{Name: "api"},
+				{
+					Name:    "web",
+					Topics:  pulumi.ToStringArray([]string{"frontend"}),
+					Policy:  &github.RepositoryPolicy{HasWiki: ptr(true)},
+					Labels:  []github.Label{{Name: "bug", Color: "FF0000"}, {Name: "design", Color: "1D76DB"}},
+					Secrets: map[string]pulumi.StringInput{"DEPLOY_KEY": pulumi.String("key")},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Len(t, set.Repositories, 2)
+		assertOutputEquals(t, set.RepositoryURLs.ToStringMapOutput(), map[string]string{
+			"api": "https://github.com/mock-owner/api",
+			"web": "https://github.com/mock-owner/web",
+		})
+		return nil
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"fleet"}, mocks.names(github.StandardRepoSetType))
+	assert.Equal(t, []string{"fleet-api", "fleet-web"}, mocks.names(github.StandardRepoType))
+
+	// The defaults apply to every repository.
+	api := mocks.inputs("fleet-api-repository")
+	assert.Equal(t, "A fleet repository", api["description"].StringValue())
+	assert.Equal(t, "pulumi", api["topics"].ArrayValue()[0].StringValue())
+	assert.False(t, api["hasDiscussions"].BoolValue())
+	assert.False(t, api["hasWiki"].BoolValue())
+	assert.Equal(t, []string{"fleet-api-label-bug", "fleet-web-label-bug", "fleet-web-label-design"},
+		mocks.names("github:index/issueLabel:IssueLabel"))
+	assert.Equal(t, "D73A4A", mocks.inputs("fleet-api-label-bug")["color"].StringValue())
+
+	// The overrides of a repository take precedence and extend the defaults.
+	web := mocks.inputs("fleet-web-repository")
+	assert.Equal(t, "frontend", web["topics"].ArrayValue()[0].StringValue())
+	assert.False(t, web["hasDiscussions"].BoolValue())
+	assert.True(t, web["hasWiki"].BoolValue())
+	assert.Equal(t, "FF0000", mocks.inputs("fleet-web-label-bug")["color"].StringValue())
+	assert.Equal(t, []string{"fleet-api-secret-npm-token", "fleet-web-secret-deploy-key", "fleet-web-secret-npm-token"},
+		mocks.names("github:index/actionsSecret:ActionsSecret"))
+}
+
+func TestNewStandardRepoSet_AggregatesErrors(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepoSet(ctx, "fleet", &github.StandardRepoSetArgs{
+			Defaults: github.StandardRepoArgs{LegacyChildNames: true},
+			Repositories: []github.RepositorySpec{
+				{Name: "api"},
+				{Name: "API"},
+				{Name: "bad name"},
+				{Name: "web", Labels: []github.Label{{Name: "bug", Color: "#d73a4a"}}},
+			},
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+
+	assert.ErrorContains(t, err, "LegacyChildNames does not apply to repository sets")
+	assert.ErrorContains(t, err, `repository "API" is declared more than once`)
+	assert.ErrorContains(t, err, `repository "bad name": repository name "bad name"`)
+	assert.ErrorContains(t, err, `repository "web": label "bug" has invalid color "#d73a4a"`)
+	// Nothing is registered while any repository is invalid.
+	assert.Empty(t, mocks.resources)
+}
+
+func TestNewStandardRepoSet_DistinctChildren(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepoSet(ctx, "fleet", &github.StandardRepoSetArgs{
+			Defaults: github.StandardRepoArgs{
+				AutoInit: ptr(true),
+				Secrets:  map[string]pulumi.StringInput{"NPM_TOKEN": pulumi.String("token")},
+			},
+			Repositories: []github.RepositorySpec{{Name: "api"}, {Name: "web"}},
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	require.NoError(t, err)
+
+	// No URN, including those of the aliases to the former type tokens, is
+	// claimed by the children of both repositories.
+	identities := mocks.identities(t)
+	set := urn("", github.StandardRepoSetType, "fleet")
+	for _, repo := range []string{"fleet-api", "fleet-web"} {
+		assert.Equal(t, repo, identities[urn(set, "custom:resource:StandardRepo", repo)])
+		component := urn(set, github.StandardRepoType, repo)
+		for _, child := range []string{"repository", "default-branch", "branch-protection", "label-gh-actions", "secret-npm-token"} {
+			name := repo + "-" + child
+			assert.Contains(t, identities, urn(component, mocks.registerRPC(t, name).TypeToken, name))
+		}
+	}
+}
+
+func TestNewStandardRepoSet_Adopt(t *testing.T) {
+	mocks := adoptMocks{recordingMocks: &recordingMocks{}, live: liveRepository}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepoSet(ctx, "fleet", &github.StandardRepoSetArgs{
+			Defaults: github.StandardRepoArgs{Adopt: true},
+			Repositories: []github.RepositorySpec{
+				{Name: "hand-made"},
+				{Name: "web", Adopt: ptr(false)},
+			},
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	require.NoError(t, err)
+
+	assert.Equal(t, "hand-made", mocks.importID("fleet-hand-made-repository"))
+	// A repository can opt out of adopting.
+	assert.Empty(t, mocks.importID("fleet-web-repository"))
+}
@@ -0,0 +1,134 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const (
+	maxRepositoryNameLength = 100
+	maxTopicLength          = 50
+	maxTopics               = 20
+)
+
+var (
+	repositoryNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+	topicPattern          = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+)
+
+// validateRepositoryName checks the name of the repository, if it is known
+// before the deployment. GitHub silently replaces unsupported characters with
+// hyphens, so they are rejected instead.
+func validateRepositoryName(input pulumi.StringInput) error {
+	if input == nil {
+		return fmt.Errorf("repository name is required")
+	}
+	repositoryName, ok := knownString(input)
+	if !ok {
+		return nil
+	}
+	if repositoryName == "" {
+		return fmt.Errorf("repository name must not be empty")
+	}
+	if n := utf8.RuneCountInString(repositoryName); n > maxRepositoryNameLength {
+		return fmt.Errorf("repository name %q is %d characters long, the maximum is %d", repositoryName, n, maxRepositoryNameLength)
+	}
+	if !repositoryNamePattern.MatchString(repositoryName) {
+		return fmt.Errorf("repository name %q may only contain letters, digits, '.', '-' and '_'", repositoryName)
+	}
+	if repositoryName == "." || repositoryName == ".." {
+		return fmt.Errorf("repository name %q is reserved", repositoryName)
+	}
+	return nil
+}
+
+// resolveTopics validates the topics if they are known before the deployment,
+// and normalizes them on request. Topics that are not yet known are only
+// normalized.
+func resolveTopics(input pulumi.StringArrayInput, normalize bool) (pulumi.StringArrayInput, error) {
+	if input == nil {
+		return nil, nil
+	}
+	topics, ok := knownStrings(input)
+	if !ok {
+		if normalize {
+			return input.ToStringArrayOutput().ApplyT(normalizeTopics).(pulumi.StringArrayOutput), nil
+		}
+		return input, nil
+	}
+	if normalize {
+		topics = normalizeTopics(topics)
+	}
+	if err := validateTopics(topics); err != nil {
+		return nil, err
+	}
+	if normalize {
+		return pulumi.ToStringArray(topics), nil
+	}
+	return input, nil
+}
+
+// validateTopics checks the topics against GitHub's rules: at most 20 topics
+// of lowercase letters, digits and hyphens, each starting with a letter or a
+// digit and at most 50 characters long, without duplicates.
+func validateTopics(topics []string) error {
+	if len(topics) > maxTopics {
+		return fmt.Errorf("at most %d topics are allowed, got %d", maxTopics, len(topics))
+	}
+	seen := make(map[string]bool)
+	for _, topic := range topics {
+		if topic == "" {
+			return fmt.Errorf("topic must not be empty")
+		}
+		if n := utf8.RuneCountInString(topic); n > maxTopicLength {
+			return fmt.Errorf("topic %q is %d characters long, the maximum is %d", topic, n, maxTopicLength)
+		}
+		if !topicPattern.MatchString(topic) {
+			return fmt.Errorf("topic %q may only contain lowercase letters, digits and hyphens and must start with a letter or digit", topic)
+		}
+		if seen[topic] {
+			return fmt.Errorf("topic %q is declared more than once", topic)
+		}
+		seen[topic] = true
+	}
+	return nil
+}
+
+// normalizeTopics lowercases, deduplicates and sorts the topics.
+func normalizeTopics(topics []string) []string {
+	normalized := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		normalized = append(normalized, strings.ToLower(topic))
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+// knownString returns the value of the input if it is known before the deployment.
+func knownString(input pulumi.StringInput) (string, bool) {
+	s, ok := input.(pulumi.String)
+	return string(s), ok
+}
+
+// knownStrings returns the values of the input if they are all known before
+// the deployment.
+func knownStrings(input pulumi.StringArrayInput) ([]string, bool) {
+	array, ok := input.(pulumi.StringArray)
+	if !ok {
+		return nil, false
+	}
+	values := make([]string, 0, len(array))
+	for _, element := range array {
+		s, ok := knownString(element)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, s)
+	}
+	return values, true
+}
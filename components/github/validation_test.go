@@ -0,0 +1,154 @@
+package github_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/softwaredevelop/pulumi-go-components/components/github"
+)
+
+func TestNewStandardRepo_InvalidInputs(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        *github.StandardRepoArgs
+		expectedMsg string
+	}{
+		{
+			"EmptyName",
+			&github.StandardRepoArgs{RepositoryName: pulumi.String("")},
+			"repository name must not be empty",
+		},
+		{
+			"NameTooLong",
+			&github.StandardRepoArgs{RepositoryName: pulumi.String(strings.Repeat("a", 101))},
+			"is 101 characters long, the maximum is 100",
+		},
+		{
+			"NameWithSpace",
+			&github.StandardRepoArgs{RepositoryName: pulumi.String("my repo")},
+			`repository name "my repo" may only contain letters, digits, '.', '-' and '_'`,
+		},
+		{
+			"ReservedName",
+			&github.StandardRepoArgs{RepositoryName: pulumi.String("..")},
+			`repository name ".." is reserved`,
+		},
+		{
+			"UppercaseTopic",
+			&github.StandardRepoArgs{Topics: pulumi.ToStringArray([]string{"Pulumi"})},
+			`topic "Pulumi" may only contain lowercase letters, digits and hyphens`,
+		},
+		{
+			"TopicStartingWithHyphen",
+			&github.StandardRepoArgs{Topics: pulumi.ToStringArray([]string{"-go"})},
+			`topic "-go" may only contain lowercase letters, digits and hyphens`,
+		},
+		{
+			"TopicTooLong",
+			&github.StandardRepoArgs{Topics: pulumi.ToStringArray([]string{strings.Repeat("a", 51)})},
+			"is 51 characters long, the maximum is 50",
+		},
+		{
+			"TooManyTopics",
+			&github.StandardRepoArgs{Topics: pulumi.ToStringArray(strings.Split("a b c d e f g h i j k l m n o p q r s t u", " "))},
+			"at most 20 topics are allowed, got 21",
+		},
+		{
+			"DuplicateTopic",
+			&github.StandardRepoArgs{Topics: pulumi.ToStringArray([]string{"go", "pulumi", "go"})},
+			`topic "go" is declared more than once`,
+		},
+		{
+			"LabelColorWithHash",
+			&github.StandardRepoArgs{Labels: []github.Label{{Name: "bug", Color: "#d73a4a"}}},
+			`label "bug" has invalid color "#d73a4a"`,
+		},
+		{
+			"SecretNameWithHyphen",
+			&github.StandardRepoArgs{Secrets: map[string]pulumi.StringInput{"NPM-TOKEN": pulumi.String("token")}},
+			`secret name "NPM-TOKEN" may only contain letters, digits and underscores`,
+		},
+		{
+			"ReservedSecretName",
+			&github.StandardRepoArgs{Secrets: map[string]pulumi.StringInput{"GITHUB_TOKEN": pulumi.String("token")}},
+			`secret name "GITHUB_TOKEN" must not start with the reserved GITHUB_ prefix`,
+		},
+		{
+			"SecretsDifferingInCase",
+			&github.StandardRepoArgs{Secrets: map[string]pulumi.StringInput{
+				"NPM_TOKEN": pulumi.String("token"),
+				"npm_token": pulumi.String("token"),
+			}},
+			`secrets "NPM_TOKEN" and "npm_token" differ only in case`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.args.RepositoryName == nil {
+				tt.args.RepositoryName = pulumi.String("test-repo")
+			}
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", tt.args)
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			assert.ErrorContains(t, err, tt.expectedMsg)
+			assert.Empty(t, mocks.resources)
+		})
+	}
+}
+
+func TestNewStandardRepo_MissingRepositoryName(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", standardRepoMocks(0)))
+	assert.ErrorContains(t, err, "repository name is required")
+}
+
+func TestNewStandardRepo_NormalizeTopics(t *testing.T) {
+	tests := []struct {
+		name   string
+		topics pulumi.StringArrayInput
+	}{
+		{"Known", pulumi.ToStringArray([]string{"Pulumi", "go", "GO", "automation"})},
+		{"Unknown", pulumi.ToStringArray([]string{"Pulumi", "go", "GO", "automation"}).ToStringArrayOutput()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName:  pulumi.String("test-repo"),
+					Topics:          tt.topics,
+					NormalizeTopics: true,
+				})
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			assert.NoError(t, err)
+
+			assert.Equal(t, []resource.PropertyValue{
+				resource.NewStringProperty("automation"),
+				resource.NewStringProperty("go"),
+				resource.NewStringProperty("pulumi"),
+			}, mocks.inputs("repo-repository")["topics"].ArrayValue())
+		})
+	}
+}
+
+func TestNewStandardRepo_UnknownInputsAreNotValidated(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo").ToStringOutput(),
+			Topics:         pulumi.StringArray{pulumi.String("go"), pulumi.String("go").ToStringOutput()},
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", standardRepoMocks(0)))
+	assert.NoError(t, err)
+}
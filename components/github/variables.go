@@ -0,0 +1,80 @@
+package github
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+var actionsNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validateActionsName checks a secret or variable name against GitHub's rules:
+// only letters, digits and underscores, not starting with a digit, and not
+// starting with the reserved GITHUB_ prefix.
+func validateActionsName(kind, name string) error {
+	if name == "" {
+		return fmt.Errorf("%s name must not be empty", kind)
+	}
+	if !actionsNamePattern.MatchString(name) {
+		return fmt.Errorf("%s name %q may only contain letters, digits and underscores and must not start with a digit", kind, name)
+	}
+	if strings.HasPrefix(strings.ToUpper(name), "GITHUB_") {
+		return fmt.Errorf("%s name %q must not start with the reserved GITHUB_ prefix", kind, name)
+	}
+	return nil
+}
+
+// validateVariables checks the variable names, and that no variable shares its
+// name with a secret. Names are compared case-insensitively, as on GitHub.
+func validateVariables(variables, secrets map[string]pulumi.StringInput) error {
+	secretNames := make(map[string]bool)
+	for secretName := range secrets {
+		secretNames[strings.ToUpper(secretName)] = true
+	}
+
+	seen := make(map[string]string)
+	for _, variableName := range slices.Sorted(maps.Keys(variables)) {
+		if err := validateActionsName("variable", variableName); err != nil {
+			return err
+		}
+		key := strings.ToUpper(variableName)
+		if other, ok := seen[key]; ok {
+			return fmt.Errorf("variables %q and %q differ only in case", other, variableName)
+		}
+		seen[key] = variableName
+		if secretNames[key] {
+			return fmt.Errorf("variable %q has the same name as a secret", variableName)
+		}
+		if variables[variableName] == nil {
+			return fmt.Errorf("variable %q has no value", variableName)
+		}
+	}
+	return nil
+}
+
+// newVariables creates an Actions variable for every entry of the map.
+func newVariables(ctx *pulumi.Context, name string, repository pulumi.StringInput, variables map[string]pulumi.StringInput, opts ...pulumi.ResourceOption) error {
+	for _, variableName := range slices.Sorted(maps.Keys(variables)) {
+		_, err := github.NewActionsVariable(ctx, childName(name, "variable-"+actionsSuffix(variableName)), &github.ActionsVariableArgs{
+			Repository:   repository,
+			VariableName: pulumi.String(variableName),
+			Value:        variables[variableName],
+		}, opts...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// actionsSuffix derives the child name suffix of a secret or variable from its
+// name. Names differing only in case are rejected by GitHub, so the mapping is
+// unique for valid names.
+func actionsSuffix(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+}
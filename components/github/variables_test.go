@@ -0,0 +1,66 @@
+package github_test
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/softwaredevelop/pulumi-go-components/components/github"
+)
+
+func TestNewStandardRepo_Variables(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			Secrets:        map[string]pulumi.StringInput{"GITLAB_TOKEN": pulumi.String("token")},
+			Variables: map[string]pulumi.StringInput{
+				"GITLAB_OWNER":      pulumi.String("group"),
+				"GITLAB_REPOSITORY": pulumi.String("gitlab.com/group/test-repo"),
+			},
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"repo-variable-gitlab-owner", "repo-variable-gitlab-repository"},
+		mocks.names("github:index/actionsVariable:ActionsVariable"))
+	inputs := mocks.inputs("repo-variable-gitlab-owner")
+	assert.Equal(t, "GITLAB_OWNER", inputs["variableName"].StringValue())
+	assert.Equal(t, "group", inputs["value"].StringValue())
+	assert.False(t, inputs["value"].IsSecret(), "variables are not sensitive")
+}
+
+func TestNewStandardRepo_InvalidVariables(t *testing.T) {
+	tests := []struct {
+		name        string
+		variables   map[string]pulumi.StringInput
+		secrets     map[string]pulumi.StringInput
+		expectedMsg string
+	}{
+		{"Space", map[string]pulumi.StringInput{"MY VAR": pulumi.String("x")}, nil, `variable name "MY VAR" may only contain letters, digits and underscores`},
+		{"Dash", map[string]pulumi.StringInput{"MY-VAR": pulumi.String("x")}, nil, `variable name "MY-VAR" may only contain`},
+		{"LeadingDigit", map[string]pulumi.StringInput{"1VAR": pulumi.String("x")}, nil, `must not start with a digit`},
+		{"ReservedPrefix", map[string]pulumi.StringInput{"github_owner": pulumi.String("x")}, nil, `must not start with the reserved GITHUB_ prefix`},
+		{"CaseDuplicate", map[string]pulumi.StringInput{"OWNER": pulumi.String("x"), "owner": pulumi.String("y")}, nil, `variables "OWNER" and "owner" differ only in case`},
+		{"SharedWithSecret", map[string]pulumi.StringInput{"gitlab_token": pulumi.String("x")}, map[string]pulumi.StringInput{"GITLAB_TOKEN": pulumi.String("y")}, `variable "gitlab_token" has the same name as a secret`},
+		{"NoValue", map[string]pulumi.StringInput{"OWNER": nil}, nil, `variable "OWNER" has no value`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName: pulumi.String("test-repo"),
+					Secrets:        tt.secrets,
+					Variables:      tt.variables,
+				})
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			assert.ErrorContains(t, err, tt.expectedMsg)
+			assert.Empty(t, mocks.resources, "nothing should be registered for invalid variables")
+		})
+	}
+}
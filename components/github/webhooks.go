@@ -0,0 +1,155 @@
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// Webhook defines a webhook of the repository.
+type Webhook struct {
+	// The name of the webhook. It identifies the webhook within the component.
+	Name string
+	// The URL the payloads are delivered to. It must be an http or https URL.
+	URL string
+	// The content type of the payloads: "json" or "form". Defaults to "json".
+	ContentType string
+	// The events that trigger the webhook. Defaults to "push".
+	Events []string
+	// Deliver payloads when the webhook is triggered. Defaults to true.
+	Active *bool
+	// The shared secret used to sign the payloads. It is stored as a Pulumi secret.
+	Secret pulumi.StringInput
+	// Skip verifying the TLS certificate of the URL.
+	InsecureSSL bool
+}
+
+var webhookContentTypes = []string{"json", "form"}
+
+// webhookEvents holds the events a repository webhook can subscribe to.
+var webhookEvents = []string{
+	"*",
+	"branch_protection_configuration",
+	"branch_protection_rule",
+	"check_run",
+	"check_suite",
+	"code_scanning_alert",
+	"commit_comment",
+	"create",
+	"delete",
+	"dependabot_alert",
+	"deploy_key",
+	"deployment",
+	"deployment_protection_rule",
+	"deployment_review",
+	"deployment_status",
+	"discussion",
+	"discussion_comment",
+	"fork",
+	"gollum",
+	"issue_comment",
+	"issues",
+	"label",
+	"merge_group",
+	"meta",
+	"milestone",
+	"package",
+	"page_build",
+	"project",
+	"project_card",
+	"project_column",
+	"public",
+	"pull_request",
+	"pull_request_review",
+	"pull_request_review_comment",
+	"pull_request_review_thread",
+	"push",
+	"registry_package",
+	"release",
+	"repository",
+	"repository_advisory",
+	"repository_import",
+	"repository_ruleset",
+	"repository_vulnerability_alert",
+	"secret_scanning_alert",
+	"secret_scanning_alert_location",
+	"security_and_analysis",
+	"star",
+	"status",
+	"team_add",
+	"watch",
+	"workflow_job",
+	"workflow_run",
+}
+
+// validateWebhooks checks the names, URLs, content types and events of the webhooks.
+func validateWebhooks(webhooks []Webhook) error {
+	names := make(map[string]string)
+	for _, hook := range webhooks {
+		suffix := slug(hook.Name)
+		if suffix == "" {
+			return fmt.Errorf("webhook name %q must contain at least one letter or digit", hook.Name)
+		}
+		if other, ok := names[suffix]; ok {
+			return fmt.Errorf("webhooks %q and %q map to the same resource name", other, hook.Name)
+		}
+		names[suffix] = hook.Name
+
+		u, err := url.Parse(hook.URL)
+		if err != nil {
+			return fmt.Errorf("webhook %q has a malformed URL: %w", hook.Name, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("webhook %q must have an absolute http or https URL, got %q", hook.Name, hook.URL)
+		}
+		if hook.ContentType != "" && !slices.Contains(webhookContentTypes, hook.ContentType) {
+			return fmt.Errorf("webhook %q has unknown content type %q", hook.Name, hook.ContentType)
+		}
+		for _, event := range hook.Events {
+			if !slices.Contains(webhookEvents, event) {
+				return fmt.Errorf("webhook %q has unknown event %q", hook.Name, event)
+			}
+		}
+	}
+	return nil
+}
+
+// newWebhooks creates the webhooks of the repository.
+func newWebhooks(ctx *pulumi.Context, name string, repository pulumi.StringInput, webhooks []Webhook, opts ...pulumi.ResourceOption) error {
+	for _, hook := range webhooks {
+		contentType := hook.ContentType
+		if contentType == "" {
+			contentType = "json"
+		}
+		events := hook.Events
+		if len(events) == 0 {
+			events = []string{"push"}
+		}
+		active := true
+		if hook.Active != nil {
+			active = *hook.Active
+		}
+
+		configuration := &github.RepositoryWebhookConfigurationArgs{
+			Url:         pulumi.String(hook.URL),
+			ContentType: pulumi.String(contentType),
+			InsecureSsl: pulumi.Bool(hook.InsecureSSL),
+		}
+		if hook.Secret != nil {
+			configuration.Secret = pulumi.ToSecret(hook.Secret).(pulumi.StringOutput)
+		}
+		_, err := github.NewRepositoryWebhook(ctx, childName(name, "webhook-"+slug(hook.Name)), &github.RepositoryWebhookArgs{
+			Repository:    repository,
+			Events:        pulumi.ToStringArray(events),
+			Active:        pulumi.Bool(active),
+			Configuration: configuration,
+		}, opts...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package github_test
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/softwaredevelop/pulumi-go-components/components/github"
+)
+
+func TestNewStandardRepo_Webhooks(t *testing.T) {
+	mocks := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+			RepositoryName: pulumi.String("test-repo"),
+			Webhooks: []github.Webhook{
+				{
+					Name:   "ci",
+					URL:    "https://ci.example.com/hooks/github",
+					Events: []string{"push", "pull_request"},
+					Secret: pulumi.String("hook-secret"),
+				},
+				{
+					Name:        "chat",
+					URL:         "https://chat.example.com/webhook",
+					ContentType: "form",
+					Active:      ptr(false),
+				},
+			},
+		})
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", mocks))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"repo-webhook-chat", "repo-webhook-ci"}, mocks.names("github:index/repositoryWebhook:RepositoryWebhook"))
+
+	ci := mocks.inputs("repo-webhook-ci")
+	assert.True(t, ci["active"].BoolValue())
+	assert.Len(t, ci["events"].ArrayValue(), 2)
+	configuration := ci["configuration"].ObjectValue()
+	assert.Equal(t, "https://ci.example.com/hooks/github", configuration["url"].StringValue())
+	assert.Equal(t, "json", configuration["contentType"].StringValue())
+	assert.True(t, configuration["secret"].IsSecret(), "the webhook secret must stay a Pulumi secret")
+
+	chat := mocks.inputs("repo-webhook-chat")
+	assert.False(t, chat["active"].BoolValue())
+	assert.Equal(t, "push", chat["events"].ArrayValue()[0].StringValue())
+	assert.Equal(t, "form", chat["configuration"].ObjectValue()["contentType"].StringValue())
+}
+
+func TestNewStandardRepo_InvalidWebhooks(t *testing.T) {
+	tests := []struct {
+		name        string
+		webhook     github.Webhook
+		expectedMsg string
+	}{
+		{"NoName", github.Webhook{URL: "https://example.com"}, `webhook name "" must contain at least one letter or digit`},
+		{"MalformedURL", github.Webhook{Name: "ci", URL: "https://exa mple.com"}, `webhook "ci" has a malformed URL`},
+		{"RelativeURL", github.Webhook{Name: "ci", URL: "/hooks/github"}, `webhook "ci" must have an absolute http or https URL`},
+		{"UnsupportedScheme", github.Webhook{Name: "ci", URL: "ftp://example.com"}, `webhook "ci" must have an absolute http or https URL`},
+		{"UnknownContentType", github.Webhook{Name: "ci", URL: "https://example.com", ContentType: "xml"}, `webhook "ci" has unknown content type "xml"`},
+		{"UnknownEvent", github.Webhook{Name: "ci", URL: "https://example.com", Events: []string{"push", "pull-request"}}, `webhook "ci" has unknown event "pull-request"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := &recordingMocks{}
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := github.NewStandardRepo(ctx, "repo", &github.StandardRepoArgs{
+					RepositoryName: pulumi.String("test-repo"),
+					Webhooks:       []github.Webhook{tt.webhook},
+				})
+				return err
+			}, pulumi.WithMocks("test-project", "test-stack", mocks))
+			assert.ErrorContains(t, err, tt.expectedMsg)
+			assert.Empty(t, mocks.resources, "nothing should be registered for invalid webhooks")
+		})
+	}
+}
@@ -0,0 +1,14 @@
+import pulumi
+import pulumi_softwaredevelop as softwaredevelop
+
+repo = softwaredevelop.github.v1.StandardRepo(
+    "repo",
+    repository_name="standard-repo-python",
+    description="Created with the StandardRepo component from Python",
+    topics=["pulumi", "python"],
+    label_catalogs=[softwaredevelop.github.v1.LabelCatalog.DEPENDABOT],
+    dependabot=[softwaredevelop.github.v1.DependabotUpdateArgs(ecosystem="pip")],
+)
+
+pulumi.export("repositoryUrl", repo.repository_url)
+pulumi.export("cloneUrl", repo.repository_ssh_clone_url)
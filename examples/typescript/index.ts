@@ -0,0 +1,12 @@
+import * as softwaredevelop from "@pulumi/softwaredevelop";
+
+const repo = new softwaredevelop.github.v1.StandardRepo("repo", {
+    repositoryName: "standard-repo-typescript",
+    description: "Created with the StandardRepo component from TypeScript",
+    topics: ["pulumi", "typescript"],
+    labelCatalogs: [softwaredevelop.github.v1.LabelCatalog.Dependabot],
+    dependabot: [{ ecosystem: "npm" }],
+});
+
+export const repositoryUrl = repo.repositoryUrl;
+export const cloneUrl = repo.repositorySshCloneUrl;
@@ -2,12 +2,21 @@
 package main
 
 import (
+	"context"
+	"slices"
+
 	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/config"
+
+	components "github.com/softwaredevelop/pulumi-go-components/components/github"
 )
 
 // GithubResources holds the created GitHub resources, making them accessible for testing and exporting.
 type GithubResources struct {
+	StandardRepo      *components.StandardRepo
 	Repository        *github.Repository
 	BranchProtection  *github.BranchProtection
 	GhActionsLabel    *github.IssueLabel
@@ -17,16 +26,61 @@ type GithubResources struct {
 	GitlabOwnerSecret *github.ActionsSecret
 }
 
+const (
+	repositoryName = "pulumi-go-components"
+	repositoryType = "github:index/repository:Repository"
+	secretType     = "github:index/actionsSecret:ActionsSecret"
+)
+
+// legacyNames maps the child resources of the component to the names they
+// were declared with before this program used StandardRepo, so that the
+// existing stack is updated in place instead of being replaced.
+var legacyNames = map[string]string{
+	repositoryName + "-repository":                    "newRepositoryPulumiGoComponents",
+	repositoryName + "-branch-protection":             "branchProtection",
+	repositoryName + "-label-gh-actions":              "newIssueLabelGhActions",
+	repositoryName + "-label-go-modules-dependencies": "newIssueLabelGoModules",
+	repositoryName + "-secret-gitlab-repo":            "newActionsSecretGLR",
+	repositoryName + "-secret-gitlab-token":           "newActionsSecretGLT",
+	repositoryName + "-secret-gitlab-owner":           "newActionsSecretGLO",
+}
+
+// legacyAliases returns a transform that aliases the children of the
+// component to their former URNs. The repository, the branch protection and
+// the labels were declared at the top of the stack, while the secrets were
+// parented to the repository.
+func legacyAliases(project, stack string) pulumi.ResourceTransform {
+	legacyURN := func(parent resource.URN, typ, name string) resource.URN {
+		var parentType tokens.Type
+		if parent != "" {
+			parentType = parent.QualifiedType()
+		}
+		return resource.NewURN(tokens.QName(stack), tokens.PackageName(project), parentType, tokens.Type(typ), name)
+	}
+	legacyRepository := legacyURN("", repositoryType, legacyNames[repositoryName+"-repository"])
+	return func(_ context.Context, args *pulumi.ResourceTransformArgs) *pulumi.ResourceTransformResult {
+		legacyName, ok := legacyNames[args.Name]
+		if !ok {
+			return nil
+		}
+		var parent resource.URN
+		if args.Type == secretType {
+			parent = legacyRepository
+		}
+		opts := args.Opts
+		opts.Aliases = slices.Concat(opts.Aliases, []pulumi.Alias{{URN: pulumi.URN(legacyURN(parent, args.Type, legacyName))}})
+		return &pulumi.ResourceTransformResult{Props: args.Props, Opts: opts}
+	}
+}
+
 // defineInfrastructure defines the GitHub resources for the project.
 // It is separated from main() to be independently testable.
 func defineInfrastructure(ctx *pulumi.Context) (*GithubResources, error) {
-	repositoryName := "pulumi-go-components"
-	repository, err := github.NewRepository(ctx, "newRepositoryPulumiGoComponents", &github.RepositoryArgs{
-		DeleteBranchOnMerge: pulumi.Bool(true),
-		Description:         pulumi.String("This is a repository for pulumi go components."),
-		HasIssues:           pulumi.Bool(true),
-		HasProjects:         pulumi.Bool(true),
-		Name:                pulumi.String(repositoryName),
+	// The values of the GitLab mirror secrets are read from the stack configuration.
+	cfg := config.New(ctx, "")
+	standardRepo, err := components.NewStandardRepo(ctx, repositoryName, &components.StandardRepoArgs{
+		RepositoryName: pulumi.String(repositoryName),
+		Description:    pulumi.String("This is a repository for pulumi go components."),
 		Topics: pulumi.StringArray{
 			pulumi.String("dagger"),
 			pulumi.String("github"),
@@ -36,74 +90,30 @@ func defineInfrastructure(ctx *pulumi.Context) (*GithubResources, error) {
 			pulumi.String("pulumi"),
 			pulumi.String("vscode"),
 		},
-		Visibility: pulumi.String("public"),
-		// VulnerabilityAlerts: pulumi.Bool(true),
-	}, pulumi.Protect(false))
-	if err != nil {
-		return nil, err
-	}
-
-	branchProtection, err := github.NewBranchProtection(ctx, "branchProtection", &github.BranchProtectionArgs{
-		RepositoryId:          repository.NodeId,
-		Pattern:               pulumi.String("main"),
-		RequiredLinearHistory: pulumi.Bool(true),
-	}, pulumi.Protect(false))
-	if err != nil {
-		return nil, err
-	}
-
-	ghActionsLabel, err := github.NewIssueLabel(ctx, "newIssueLabelGhActions", &github.IssueLabelArgs{
-		Color:       pulumi.String("E66E01"),
-		Description: pulumi.String("This issue is related to github-actions dependencies"),
-		Name:        pulumi.String("github-actions dependencies"),
-		Repository:  repository.Name,
-	}, pulumi.Protect(false))
-	if err != nil {
-		return nil, err
-	}
-
-	goModulesLabel, err := github.NewIssueLabel(ctx, "newIssueLabelGoModules", &github.IssueLabelArgs{
-		Color:       pulumi.String("9BE688"),
-		Description: pulumi.String("This issue is related to go modules dependencies"),
-		Name:        pulumi.String("go-modules dependencies"),
-		Repository:  repository.Name,
-	}, pulumi.Protect(false))
-	if err != nil {
-		return nil, err
-	}
-
-	gitlabRepoSecret, err := github.NewActionsSecret(ctx, "newActionsSecretGLR", &github.ActionsSecretArgs{
-		Repository: repository.Name,
-		SecretName: pulumi.String("GITLAB_REPOSITORY"),
-	}, pulumi.Parent(repository), pulumi.Protect(false))
-	if err != nil {
-		return nil, err
-	}
-
-	gitlabTokenSecret, err := github.NewActionsSecret(ctx, "newActionsSecretGLT", &github.ActionsSecretArgs{
-		Repository: repository.Name,
-		SecretName: pulumi.String("GITLAB_TOKEN"),
-	}, pulumi.Parent(repository), pulumi.Protect(false))
-	if err != nil {
-		return nil, err
-	}
-
-	gitlabOwnerSecret, err := github.NewActionsSecret(ctx, "newActionsSecretGLO", &github.ActionsSecretArgs{
-		Repository: repository.Name,
-		SecretName: pulumi.String("GITLAB_OWNER"),
-	}, pulumi.Parent(repository), pulumi.Protect(false))
+		Labels: []components.Label{
+			{Name: "github-actions dependencies", Color: "E66E01", Description: "This issue is related to github-actions dependencies"},
+			{Name: "go-modules dependencies", Color: "9BE688", Description: "This issue is related to go modules dependencies"},
+		},
+		Secrets: components.GitLabMirrorSecrets(
+			pulumi.String(cfg.Require("gitlabRepository")),
+			cfg.RequireSecret("gitlabToken"),
+			pulumi.String(cfg.Require("gitlabOwner")),
+		),
+		Transforms: []pulumi.ResourceTransform{legacyAliases(ctx.Project(), ctx.Stack())},
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	return &GithubResources{
-		Repository:        repository,
-		BranchProtection:  branchProtection,
-		GhActionsLabel:    ghActionsLabel,
-		GoModulesLabel:    goModulesLabel,
-		GitlabRepoSecret:  gitlabRepoSecret,
-		GitlabTokenSecret: gitlabTokenSecret,
-		GitlabOwnerSecret: gitlabOwnerSecret,
+		StandardRepo:      standardRepo,
+		Repository:        standardRepo.Repository,
+		BranchProtection:  standardRepo.BranchProtection,
+		GhActionsLabel:    standardRepo.Labels["github-actions dependencies"],
+		GoModulesLabel:    standardRepo.Labels["go-modules dependencies"],
+		GitlabRepoSecret:  standardRepo.Secrets["GITLAB_REPOSITORY"],
+		GitlabTokenSecret: standardRepo.Secrets["GITLAB_TOKEN"],
+		GitlabOwnerSecret: standardRepo.Secrets["GITLAB_OWNER"],
 	}, nil
 }
 
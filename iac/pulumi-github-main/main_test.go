@@ -2,12 +2,19 @@
 package main
 
 import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
 	"testing"
 
 	"github.com/pulumi/pulumi-github/sdk/v6/go/github"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/softwaredevelop/pulumi-go-components/pulumitest"
 )
 
 // mocks implements the pulumi.Mock interface for component testing.
@@ -51,6 +58,20 @@ func (m mocks) Call(_ pulumi.MockCallArgs) (resource.PropertyMap, error) {
 	return resource.PropertyMap{}, nil
 }
 
+// testConfig is the stack configuration of the tests.
+var testConfig = map[string]string{
+	"test-project:gitlabRepository": "softwaredevelop/pulumi-go-components",
+	"test-project:gitlabToken":      "gitlab-token",
+	"test-project:gitlabOwner":      "softwaredevelop",
+}
+
+// withConfig sets the stack configuration of the program.
+func withConfig(config map[string]string) pulumi.RunOption {
+	return func(info *pulumi.RunInfo) {
+		info.Config = config
+	}
+}
+
 // assertOutputEquals is a helper function to reduce boilerplate in tests.
 // It applies an assertion to a Pulumi output.
 func assertOutputEquals[T any](t *testing.T, output pulumi.Output, expected T, msgAndArgs ...any) {
@@ -143,7 +164,118 @@ func TestDefineInfrastructure(t *testing.T) {
 		}
 
 		return nil
-	}, pulumi.WithMocks("test-project", "test-stack", mocks(0)))
+	}, pulumi.WithMocks("test-project", "test-stack", mocks(0)), withConfig(testConfig))
 
 	assert.NoError(t, err)
 }
+
+// recordingMocks records the registration of every resource.
+type recordingMocks struct {
+	mocks
+
+	mu        sync.Mutex
+	resources []pulumi.MockResourceArgs
+}
+
+func (m *recordingMocks) NewResource(args pulumi.MockResourceArgs) (string, resource.PropertyMap, error) {
+	m.mu.Lock()
+	m.resources = append(m.resources, args)
+	m.mu.Unlock()
+	return m.mocks.NewResource(args)
+}
+
+// stack is the stack of the mocks.
+var stack = pulumitest.Stack{Project: "test-project", Stack: "test-stack"}
+
+func TestDefineInfrastructure_KeepsResources(t *testing.T) {
+	m := &recordingMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := defineInfrastructure(ctx)
+		return err
+	}, pulumi.WithMocks("test-project", "test-stack", m), withConfig(testConfig))
+	require.NoError(t, err)
+
+	// The mocks do not run transforms, so the transforms registered with the
+	// children are invoked here to add their aliases.
+	inputs := make(map[string]resource.PropertyMap)
+	for _, r := range m.resources {
+		if r.Name != repositoryName {
+			assert.Len(t, r.RegisterRPC.GetTransforms(), 1, "%s should be transformed", r.Name)
+		}
+		inputs[r.Name] = r.Inputs
+	}
+	resources, err := pulumitest.Transformed(context.Background(), m.resources)
+	require.NoError(t, err)
+
+	// The resources of the stack before it used StandardRepo.
+	repository := stack.URN("", "github:index/repository:Repository", "newRepositoryPulumiGoComponents")
+	existing := map[resource.URN]string{
+		repository: "pulumi-go-components-repository",
+		stack.URN("", "github:index/branchProtection:BranchProtection", "branchProtection"):      "pulumi-go-components-branch-protection",
+		stack.URN("", "github:index/issueLabel:IssueLabel", "newIssueLabelGhActions"):            "pulumi-go-components-label-gh-actions",
+		stack.URN("", "github:index/issueLabel:IssueLabel", "newIssueLabelGoModules"):            "pulumi-go-components-label-go-modules-dependencies",
+		stack.URN(repository, "github:index/actionsSecret:ActionsSecret", "newActionsSecretGLR"): "pulumi-go-components-secret-gitlab-repo",
+		stack.URN(repository, "github:index/actionsSecret:ActionsSecret", "newActionsSecretGLT"): "pulumi-go-components-secret-gitlab-token",
+		stack.URN(repository, "github:index/actionsSecret:ActionsSecret", "newActionsSecretGLO"): "pulumi-go-components-secret-gitlab-owner",
+	}
+
+	// Every existing resource is updated in place by a resource of the component.
+	identities, err := stack.Identities(resources)
+	require.NoError(t, err)
+	for old, name := range existing {
+		assert.Equal(t, name, identities[old], "%s should be aliased", old)
+	}
+
+	// The component adds only itself.
+	var added []string
+	for _, r := range m.resources {
+		if !slices.Contains(slices.Collect(maps.Values(existing)), r.Name) {
+			added = append(added, r.TypeToken+"::"+r.Name)
+		}
+	}
+	assert.Equal(t, []string{"softwaredevelop:github/v1:StandardRepo::pulumi-go-components"}, added)
+
+	// The resources send the inputs of the old program, so that the settings
+	// it left to GitHub stay unmanaged.
+	assert.Equal(t, map[string]any{
+		"deleteBranchOnMerge": true,
+		"description":         "This is a repository for pulumi go components.",
+		"hasIssues":           true,
+		"hasProjects":         true,
+		"name":                "pulumi-go-components",
+		"topics":              []any{"dagger", "github", "gitlab", "go", "golang", "pulumi", "vscode"},
+		"visibility":          "public",
+	}, inputs["pulumi-go-components-repository"].Mappable())
+	assert.Equal(t, map[string]any{
+		"repositoryId":          "mock-node-id-for-pulumi-go-components-repository",
+		"pattern":               "main",
+		"requiredLinearHistory": true,
+	}, inputs["pulumi-go-components-branch-protection"].Mappable())
+	assert.Equal(t, map[string]any{
+		"color":       "E66E01",
+		"description": "This issue is related to github-actions dependencies",
+		"name":        "github-actions dependencies",
+		"repository":  "pulumi-go-components",
+	}, inputs["pulumi-go-components-label-gh-actions"].Mappable())
+	assert.Equal(t, map[string]any{
+		"color":       "9BE688",
+		"description": "This issue is related to go modules dependencies",
+		"name":        "go-modules dependencies",
+		"repository":  "pulumi-go-components",
+	}, inputs["pulumi-go-components-label-go-modules-dependencies"].Mappable())
+
+	// The secrets, which the old program declared without a value, get their
+	// values from the stack configuration.
+	for name, secret := range map[string]struct{ secretName, configKey string }{
+		"pulumi-go-components-secret-gitlab-repo":  {"GITLAB_REPOSITORY", "test-project:gitlabRepository"},
+		"pulumi-go-components-secret-gitlab-token": {"GITLAB_TOKEN", "test-project:gitlabToken"},
+		"pulumi-go-components-secret-gitlab-owner": {"GITLAB_OWNER", "test-project:gitlabOwner"},
+	} {
+		secretInputs := inputs[name]
+		assert.Equal(t, "pulumi-go-components", secretInputs["repository"].StringValue())
+		assert.Equal(t, secret.secretName, secretInputs["secretName"].StringValue())
+		value := secretInputs["plaintextValue"]
+		require.True(t, value.IsSecret(), "the value of %s should be a secret", name)
+		assert.Equal(t, testConfig[secret.configKey], value.SecretValue().Element.StringValue())
+	}
+}
@@ -0,0 +1,116 @@
+// Package provider serves the components of this module as a Pulumi component
+// provider, so that programs written in other languages, such as YAML,
+// TypeScript and Python, can instantiate them.
+package provider
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil"
+	pulumiprovider "github.com/pulumi/pulumi/sdk/v3/go/pulumi/provider"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/softwaredevelop/pulumi-go-components/components/github"
+)
+
+// Name is the name of the Pulumi package served by the provider.
+const Name = "softwaredevelop"
+
+// Version is the version of the provider. Release builds set it with
+// -ldflags "-X github.com/softwaredevelop/pulumi-go-components/provider.Version=...".
+var Version = "0.1.0"
+
+// Main serves the provider to the Pulumi engine, whose address is passed as
+// the only positional argument. It blocks until the engine stops the provider.
+func Main() error {
+	tracing := flag.String("tracing", "", "Emit tracing to a Zipkin-compatible tracing endpoint")
+	flag.Parse()
+	logging.InitLogging(false, 0, false)
+	cmdutil.InitTracing(Name, Name, *tracing)
+
+	if flag.NArg() == 0 {
+		return errors.New("could not connect to the engine: missing engine address")
+	}
+	engine, err := grpc.NewClient(flag.Arg(0),
+		grpc.WithTransportCredentials(insecure.NewCredentials()), rpcutil.GrpcChannelOptions())
+	if err != nil {
+		return fmt.Errorf("could not connect to the engine: %w", err)
+	}
+	defer engine.Close()
+
+	schema, err := Schema()
+	if err != nil {
+		return err
+	}
+	handle, err := rpcutil.ServeWithOptions(rpcutil.ServeOptions{
+		Init: func(srv *grpc.Server) error {
+			pulumirpc.RegisterResourceProviderServer(srv, &server{engine: engine, schema: schema})
+			return nil
+		},
+		Options: rpcutil.OpenTracingServerInterceptorOptions(nil),
+	})
+	if err != nil {
+		return fmt.Errorf("could not start the provider: %w", err)
+	}
+
+	// The engine reads the port of the provider from its first line of output.
+	fmt.Printf("%d\n", handle.Port)
+	return <-handle.Done
+}
+
+// server implements the gRPC interface of a component provider. Components
+// only need Construct, so the CRUD operations of custom resources are left
+// unimplemented.
+type server struct {
+	pulumirpc.UnimplementedResourceProviderServer
+
+	engine *grpc.ClientConn
+	schema []byte
+}
+
+func (s *server) GetPluginInfo(context.Context, *emptypb.Empty) (*pulumirpc.PluginInfo, error) {
+	return &pulumirpc.PluginInfo{Version: Version}, nil
+}
+
+func (s *server) GetSchema(_ context.Context, req *pulumirpc.GetSchemaRequest) (*pulumirpc.GetSchemaResponse, error) {
+	if req.GetVersion() != 0 {
+		return nil, fmt.Errorf("unsupported schema version %d", req.GetVersion())
+	}
+	return &pulumirpc.GetSchemaResponse{Schema: string(s.schema)}, nil
+}
+
+// CheckConfig accepts any configuration, as the provider has none of its own.
+func (s *server) CheckConfig(_ context.Context, req *pulumirpc.CheckRequest) (*pulumirpc.CheckResponse, error) {
+	return &pulumirpc.CheckResponse{Inputs: req.GetNews()}, nil
+}
+
+func (s *server) DiffConfig(context.Context, *pulumirpc.DiffRequest) (*pulumirpc.DiffResponse, error) {
+	return &pulumirpc.DiffResponse{}, nil
+}
+
+// Configure asks the engine for secrets, resource references and outputs, so
+// that the inputs of the components keep their dependencies.
+func (s *server) Configure(context.Context, *pulumirpc.ConfigureRequest) (*pulumirpc.ConfigureResponse, error) {
+	return &pulumirpc.ConfigureResponse{
+		AcceptSecrets:   true,
+		SupportsPreview: true,
+		AcceptResources: true,
+		AcceptOutputs:   true,
+	}, nil
+}
+
+func (s *server) Construct(ctx context.Context, req *pulumirpc.ConstructRequest) (*pulumirpc.ConstructResponse, error) {
+	return pulumiprovider.Construct(ctx, req, s.engine, github.Construct)
+}
+
+func (s *server) Cancel(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/softwaredevelop/pulumi-go-components/components/github"
+)
+
+//go:generate go test -run TestSchema -update
+
+// module is the versioned module of the component tokens, see github.StandardRepoType.
+const module = "github/v1"
+
+// githubSchema is the schema of the GitHub provider whose resources the
+// components expose. It must match the version of the Go SDK in go.mod.
+const githubSchema = "/github/v6.7.2/schema.json"
+
+// packageSpec, resourceSpec, typeSpec and propertySpec model the subset of the
+// Pulumi package schema used by the components.
+type packageSpec struct {
+	Name        string                  `json:"name"`
+	DisplayName string                  `json:"displayName"`
+	Version     string                  `json:"version"`
+	Description string                  `json:"description"`
+	Keywords    []string                `json:"keywords"`
+	Repository  string                  `json:"repository"`
+	Publisher   string                  `json:"publisher"`
+	Types       map[string]typeSpec     `json:"types"`
+	Resources   map[string]resourceSpec `json:"resources"`
+	Language    map[string]any          `json:"language"`
+}
+
+type resourceSpec struct {
+	Description     string                  `json:"description"`
+	IsComponent     bool                    `json:"isComponent"`
+	Properties      map[string]propertySpec `json:"properties"`
+	Required        []string                `json:"required"`
+	InputProperties map[string]propertySpec `json:"inputProperties"`
+	RequiredInputs  []string                `json:"requiredInputs"`
+}
+
+type typeSpec struct {
+	Description string                  `json:"description"`
+	Type        string                  `json:"type"`
+	Properties  map[string]propertySpec `json:"properties,omitempty"`
+	Required    []string                `json:"required,omitempty"`
+	Enum        []enumSpec              `json:"enum,omitempty"`
+}
+
+type enumSpec struct {
+	Value string `json:"value"`
+}
+
+type propertySpec struct {
+	Description          string        `json:"description,omitempty"`
+	Type                 string        `json:"type,omitempty"`
+	Ref                  string        `json:"$ref,omitempty"`
+	Items                *propertySpec `json:"items,omitempty"`
+	AdditionalProperties *propertySpec `json:"additionalProperties,omitempty"`
+	Plain                bool          `json:"plain,omitempty"`
+	Secret               bool          `json:"secret,omitempty"`
+}
+
+// Schema returns the package schema of the provider, as written to schema.json.
+func Schema() ([]byte, error) {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(packageSchema()); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func packageSchema() packageSpec {
+	return packageSpec{
+		Name:        Name,
+		DisplayName: "softwaredevelop components",
+		Version:     Version,
+		Description: "Pulumi components that create GitHub repositories following a common standard.",
+		Keywords:    []string{"pulumi", "github", "category/utility", "kind/component"},
+		Repository:  "https://github.com/softwaredevelop/pulumi-go-components",
+		Publisher:   "softwaredevelop",
+		Types: map[string]typeSpec{
+			typeToken("Label"): {
+				Description: "An issue label of the repository.",
+				Type:        "object",
+				Properties: map[string]propertySpec{
+					"name":        {Description: "The name of the label, at most 50 characters.", Type: "string", Plain: true},
+					"color":       {Description: "The color of the label as a 6 digit hex code, without the leading '#'.", Type: "string", Plain: true},
+					"description": {Description: "A short description of the label, at most 100 characters.", Type: "string", Plain: true},
+				},
+				Required: []string{"name", "color"},
+			},
+			typeToken("DependabotUpdate"): {
+				Description: "Defines how Dependabot keeps the dependencies of a package ecosystem up to date.",
+				Type:        "object",
+				Properties: map[string]propertySpec{
+					"ecosystem":             {Description: `The package ecosystem, such as "gomod", "github-actions" or "npm".`, Type: "string", Plain: true},
+					"directory":             {Description: `The directory of the package manifests, relative to the repository root. Defaults to "/".`, Type: "string", Plain: true},
+					"interval":              {Description: `How often to check for updates: "daily", "weekly", "monthly", "quarterly", "semiannually" or "yearly". Defaults to "weekly".`, Type: "string", Plain: true},
+					"day":                   {Description: `The day of the week weekly updates run on, such as "monday".`, Type: "string", Plain: true},
+					"time":                  {Description: `The time of day updates run at, in "hh:mm" format.`, Type: "string", Plain: true},
+					"timeZone":              {Description: `The time zone of time, such as "Europe/Budapest". Defaults to UTC.`, Type: "string", Plain: true},
+					"openPullRequestsLimit": {Description: "The maximum number of open pull requests. Zero keeps Dependabot's default of 5.", Type: "integer", Plain: true},
+				},
+				Required: []string{"ecosystem"},
+			},
+			typeToken("RepositoryProfile"): enumType("A predefined repository policy.",
+				github.ProfileOpenSource, github.ProfileInternal, github.ProfilePrivateConfidential),
+			typeToken("ProtectionMode"): enumType("How the branches of the repository are protected.",
+				github.ProtectionBranchProtection, github.ProtectionRuleset),
+			typeToken("LabelCatalog"): enumType("A built-in set of labels.",
+				github.LabelCatalogDependabot, github.LabelCatalogTriage, github.LabelCatalogRelease),
+			typeToken("Permission"): enumType("The level of access granted to a team or collaborator.",
+				github.PermissionPull, github.PermissionTriage, github.PermissionPush, github.PermissionMaintain, github.PermissionAdmin),
+			typeToken("Lifecycle"): enumType("What happens to the repository when the component is deleted.",
+				github.LifecycleDelete, github.LifecycleArchive, github.LifecycleProtect),
+		},
+		Resources: map[string]resourceSpec{
+			github.StandardRepoType: standardRepoSchema(),
+		},
+		Language: map[string]any{
+			"nodejs": map[string]any{
+				"dependencies": map[string]string{"@pulumi/github": "^6.7.2"},
+			},
+			"python": map[string]any{
+				"requires": map[string]string{"pulumi": ">=3.0.0,<4.0.0", "pulumi-github": ">=6.7.2,<7.0.0"},
+			},
+		},
+	}
+}
+
+func standardRepoSchema() resourceSpec {
+	return resourceSpec{
+		Description: "A GitHub repository created following a common standard, with its default branch, " +
+			"branch protection, labels, secrets and access. Only a subset of the Go arguments is available " +
+			"from other languages: the policy and merge policy overrides, the ruleset, environments, webhooks, " +
+			"Actions policy, files and their commit author, security overrides, and the resource options, " +
+			"provider and transforms of the children can only be set from Go.",
+		IsComponent: true,
+		InputProperties: map[string]propertySpec{
+			"repositoryName":         {Description: "The name of the repository to be created on GitHub.", Type: "string"},
+			"description":            {Description: "The description of the repository.", Type: "string"},
+			"legacyChildNames":       {Description: "Alias the children to the fixed names they had before child names were derived from the component name. At most one component of a stack may set it.", Type: "boolean", Plain: true},
+			"topics":                 {Description: "The topics to be assigned to the repository.", Type: "array", Items: &propertySpec{Type: "string"}},
+			"normalizeTopics":        {Description: "Lowercase, deduplicate and sort the topics instead of rejecting uppercase and duplicate topics.", Type: "boolean", Plain: true},
+			"autoInit":               {Description: "Create the repository with an initial commit, so that the default branch exists before it is protected. Unset leaves it to GitHub. It does not apply to adopted repositories.", Type: "boolean", Plain: true},
+			"gitignoreTemplate":      {Description: `The gitignore template of the initial commit, such as "Go". Requires autoInit.`, Type: "string", Plain: true},
+			"licenseTemplate":        {Description: `The license template of the initial commit, such as "mit". Requires autoInit.`, Type: "string", Plain: true},
+			"defaultBranch":          {Description: `The name of the default branch. Defaults to "main". It is only managed when it is set or autoInit is enabled.`, Type: "string", Plain: true},
+			"profile":                {Description: "The policy profile of the repository. Without a profile, the repository is public and its other settings are left to GitHub.", Ref: localRef("RepositoryProfile"), Plain: true},
+			"protectionMode":         {Description: `How the branches are protected. Defaults to "branch-protection".`, Ref: localRef("ProtectionMode"), Plain: true},
+			"labels":                 {Description: "The issue labels of the repository. They override catalog labels of the same name.", Type: "array", Items: &propertySpec{Ref: localRef("Label"), Plain: true}, Plain: true},
+			"labelCatalogs":          {Description: "The built-in label catalogs to create.", Type: "array", Items: &propertySpec{Ref: localRef("LabelCatalog"), Plain: true}, Plain: true},
+			"authoritativeLabels":    {Description: "Manage the labels authoritatively, removing every label that is not declared.", Type: "boolean", Plain: true},
+			"secrets":                {Description: "The Actions secrets of the repository, keyed by secret name.", Type: "object", AdditionalProperties: &propertySpec{Type: "string"}, Plain: true, Secret: true},
+			"encryptSecrets":         {Description: "Encrypt the secret values client-side against the repository's Actions public key.", Type: "boolean", Plain: true},
+			"stableSealedSecrets":    {Description: "The names of the secrets whose ciphertext is derived deterministically, so that an unchanged secret shows no diff. Only list high-entropy values. Requires encryptSecrets.", Type: "array", Items: &propertySpec{Type: "string"}, Plain: true},
+			"variables":              {Description: "The Actions variables of the repository, keyed by variable name.", Type: "object", AdditionalProperties: &propertySpec{Type: "string"}, Plain: true},
+			"teams":                  {Description: "The teams granted access to the repository, keyed by team slug.", Type: "object", AdditionalProperties: &propertySpec{Ref: localRef("Permission"), Plain: true}, Plain: true},
+			"collaborators":          {Description: "The users granted access to the repository, keyed by login.", Type: "object", AdditionalProperties: &propertySpec{Ref: localRef("Permission"), Plain: true}, Plain: true},
+			"authoritativeAccess":    {Description: "Manage access authoritatively, revoking the access of every team and user that is not declared.", Type: "boolean", Plain: true},
+			"lifecycle":              {Description: `What happens to the repository when the component is deleted. Defaults to "delete".`, Ref: localRef("Lifecycle"), Plain: true},
+			"protectChildren":        {Description: "Protect the other child resources, so that deleting them fails until the protection is lifted.", Type: "boolean", Plain: true},
+			"retainChildrenOnDelete": {Description: "Leave the other child resources in place when they are deleted.", Type: "boolean", Plain: true},
+			"overwriteFilesOnCreate": {Description: "Overwrite files that already exist in the repository when they are created.", Type: "boolean", Plain: true},
+			"dependabot":             {Description: "The package ecosystems kept up to date by Dependabot.", Type: "array", Items: &propertySpec{Ref: localRef("DependabotUpdate"), Plain: true}, Plain: true},
+			"adopt":                  {Description: "Adopt an existing repository instead of creating it.", Type: "boolean", Plain: true},
+		},
+		RequiredInputs: []string{"repositoryName"},
+		Properties: map[string]propertySpec{
+			"repositoryName":         {Description: "The name of the repository.", Type: "string"},
+			"repositoryUrl":          {Description: "The URL of the repository on GitHub.", Type: "string"},
+			"repositoryNodeId":       {Description: "The GraphQL node ID of the repository.", Type: "string"},
+			"repositoryId":           {Description: "The numeric ID of the repository.", Type: "integer"},
+			"repositoryFullName":     {Description: "The full name of the repository, in owner/name form.", Type: "string"},
+			"repositorySshCloneUrl":  {Description: "The URL to clone the repository over SSH.", Type: "string"},
+			"repositoryHttpCloneUrl": {Description: "The URL to clone the repository over HTTPS.", Type: "string"},
+			"defaultBranch":          {Description: "The name of the default branch.", Type: "string"},
+			"repository":             {Description: "The underlying repository resource.", Ref: githubRef("repository:Repository")},
+			"branchProtection":       {Description: "The classic protection of the default branch, unset in ruleset mode.", Ref: githubRef("branchProtection:BranchProtection")},
+			"ruleset":                {Description: "The ruleset protecting the repository in ruleset mode, unset otherwise.", Ref: githubRef("repositoryRuleset:RepositoryRuleset")},
+			"labels":                 {Description: "The issue labels of the repository, keyed by label name. With authoritative labels, issueLabels manages them as well.", Type: "object", AdditionalProperties: &propertySpec{Ref: githubRef("issueLabel:IssueLabel")}},
+			"issueLabels":            {Description: "The resource managing all labels with authoritative labels, unset otherwise.", Ref: githubRef("issueLabels:IssueLabels")},
+			"secrets":                {Description: "The Actions secrets of the repository, keyed by secret name.", Type: "object", AdditionalProperties: &propertySpec{Ref: githubRef("actionsSecret:ActionsSecret")}},
+			"environments":           {Description: "The deployment environments of the repository, keyed by environment name.", Type: "object", AdditionalProperties: &propertySpec{Ref: githubRef("repositoryEnvironment:RepositoryEnvironment")}},
+			"drift":                  {Description: "The settings where the adopted repository differs from the standard. Only set in adopt mode.", Type: "array", Items: &propertySpec{Type: "string"}},
+		},
+		Required: []string{
+			"defaultBranch",
+			"environments",
+			"labels",
+			"repository",
+			"repositoryFullName",
+			"repositoryHttpCloneUrl",
+			"repositoryId",
+			"repositoryName",
+			"repositoryNodeId",
+			"repositorySshCloneUrl",
+			"repositoryUrl",
+			"secrets",
+		},
+	}
+}
+
+// enumType returns the schema of a string enum with the given values.
+func enumType[T ~string](description string, values ...T) typeSpec {
+	spec := typeSpec{Description: description, Type: "string"}
+	for _, value := range values {
+		spec.Enum = append(spec.Enum, enumSpec{Value: string(value)})
+	}
+	return spec
+}
+
+// typeToken returns the token of a type of the versioned github module.
+func typeToken(name string) string {
+	return Name + ":" + module + ":" + name
+}
+
+// localRef refers to a type of the package.
+func localRef(name string) string {
+	return "#/types/" + typeToken(name)
+}
+
+// githubRef refers to a resource of the GitHub provider, such as "repository:Repository".
+func githubRef(resource string) string {
+	return githubSchema + "#/resources/github:index%2F" + resource
+}
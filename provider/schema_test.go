@@ -0,0 +1,73 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/softwaredevelop/pulumi-go-components/provider"
+)
+
+var update = flag.Bool("update", false, "update the golden schema.json")
+
+// TestSchema compares the generated schema with the golden schema.json, from
+// which the SDKs of the other languages are generated. Run go generate to
+// update it after changing the components.
+func TestSchema(t *testing.T) {
+	schema, err := provider.Schema()
+	require.NoError(t, err)
+
+	if *update {
+		require.NoError(t, os.WriteFile("schema.json", schema, 0o644))
+	}
+	golden, err := os.ReadFile("schema.json")
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), string(schema), "schema.json is out of date, run go generate ./provider")
+}
+
+func TestSchema_References(t *testing.T) {
+	schema, err := provider.Schema()
+	require.NoError(t, err)
+
+	var spec struct {
+		Types     map[string]json.RawMessage `json:"types"`
+		Resources map[string]struct {
+			InputProperties map[string]json.RawMessage `json:"inputProperties"`
+			RequiredInputs  []string                   `json:"requiredInputs"`
+			Properties      map[string]json.RawMessage `json:"properties"`
+			Required        []string                   `json:"required"`
+		} `json:"resources"`
+	}
+	require.NoError(t, json.Unmarshal(schema, &spec))
+
+	resource, ok := spec.Resources["softwaredevelop:github/v1:StandardRepo"]
+	require.True(t, ok, "the schema should define StandardRepo")
+	for _, name := range resource.RequiredInputs {
+		assert.Contains(t, resource.InputProperties, name)
+	}
+	for _, name := range resource.Required {
+		assert.Contains(t, resource.Properties, name)
+	}
+	// Every type referenced by the inputs must be defined by the package.
+	type reference struct {
+		Ref string `json:"$ref"`
+	}
+	for name, property := range resource.InputProperties {
+		var p struct {
+			reference
+			Items                *reference `json:"items"`
+			AdditionalProperties *reference `json:"additionalProperties"`
+		}
+		require.NoError(t, json.Unmarshal(property, &p))
+		for _, r := range []*reference{&p.reference, p.Items, p.AdditionalProperties} {
+			if r != nil && r.Ref != "" {
+				assert.Contains(t, spec.Types, strings.TrimPrefix(r.Ref, "#/types/"), "%s refers to an undefined type", name)
+			}
+		}
+	}
+}
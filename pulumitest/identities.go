@@ -0,0 +1,133 @@
+// Package pulumitest helps tests prove that a program updates an existing
+// stack in place. It resolves the resources registered with Pulumi mocks into
+// the URNs the engine matches them with, including their aliases.
+package pulumitest
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+// Stack names the project and stack of the mocks.
+type Stack struct {
+	Project string
+	Stack   string
+}
+
+// URN returns the URN of a resource of the stack.
+func (s Stack) URN(parent resource.URN, typ, name string) resource.URN {
+	var parentType tokens.Type
+	if parent != "" {
+		parentType = parent.QualifiedType()
+	}
+	return resource.NewURN(tokens.QName(s.Stack), tokens.PackageName(s.Project), parentType, tokens.Type(typ), name)
+}
+
+// Resource is a registered resource and the aliases it was registered with.
+type Resource struct {
+	Name    string
+	Type    string
+	Parent  resource.URN
+	Aliases []*pulumirpc.Alias
+}
+
+// Registered returns the resources of the registrations recorded by mocks.
+func Registered(registrations []pulumi.MockResourceArgs) []Resource {
+	resources := make([]Resource, 0, len(registrations))
+	for _, r := range registrations {
+		if r.RegisterRPC == nil {
+			continue
+		}
+		resources = append(resources, Resource{
+			Name:    r.Name,
+			Type:    r.TypeToken,
+			Parent:  resource.URN(r.RegisterRPC.GetParent()),
+			Aliases: r.RegisterRPC.GetAliases(),
+		})
+	}
+	return resources
+}
+
+// Identities resolves the URN of every resource together with the URNs of
+// its aliases, the way the engine does when it diffs a stack: omitted alias
+// fields default to those of the resource, and every alias of a parent is
+// applied to the URN and the aliases of its children. It maps each URN to
+// the name of the resource, and a resource whose identities include a URN of
+// the stack updates it in place. A URN claimed by two resources is an error.
+func (s Stack) Identities(resources []Resource) (map[resource.URN]string, error) {
+	byURN := make(map[resource.URN]Resource)
+	for _, r := range resources {
+		byURN[s.URN(r.Parent, r.Type, r.Name)] = r
+	}
+
+	resolved := make(map[resource.URN][]resource.URN)
+	var resolve func(self resource.URN) ([]resource.URN, error)
+	resolve = func(self resource.URN) ([]resource.URN, error) {
+		if aliases, ok := resolved[self]; ok {
+			return aliases, nil
+		}
+		r := byURN[self]
+
+		type spec struct{ name, typ string }
+		specs := []spec{{r.Name, r.Type}}
+		var aliases []resource.URN
+		for _, alias := range r.Aliases {
+			if alias.GetUrn() != "" {
+				aliases = append(aliases, resource.URN(alias.GetUrn()))
+				continue
+			}
+			aliasSpec := alias.GetSpec()
+			if aliasSpec.GetProject() != "" || aliasSpec.GetStack() != "" {
+				return nil, fmt.Errorf("%s: aliases to other projects and stacks are not resolved", self)
+			}
+			name, typ := r.Name, r.Type
+			if aliasSpec.GetName() != "" {
+				name = aliasSpec.GetName()
+			}
+			if aliasSpec.GetType() != "" {
+				typ = aliasSpec.GetType()
+			}
+			parent := r.Parent
+			if aliasSpec.GetNoParent() {
+				parent = ""
+			} else if aliasSpec.GetParentUrn() != "" {
+				parent = resource.URN(aliasSpec.GetParentUrn())
+			}
+			aliases = append(aliases, s.URN(parent, typ, name))
+			specs = append(specs, spec{name, typ})
+		}
+		if _, ok := byURN[r.Parent]; ok {
+			parentAliases, err := resolve(r.Parent)
+			if err != nil {
+				return nil, err
+			}
+			for _, parentAlias := range parentAliases {
+				for _, spec := range specs {
+					aliases = append(aliases, s.URN(parentAlias, spec.typ, spec.name))
+				}
+			}
+		}
+		resolved[self] = aliases
+		return aliases, nil
+	}
+
+	identities := make(map[resource.URN]string)
+	for self, r := range byURN {
+		identities[self] = r.Name
+		aliases, err := resolve(self)
+		if err != nil {
+			return nil, err
+		}
+		for _, alias := range aliases {
+			if other, ok := identities[alias]; ok && other != r.Name {
+				return nil, fmt.Errorf("%s is claimed by both %s and %s", alias, other, r.Name)
+			}
+			identities[alias] = r.Name
+		}
+	}
+	return identities, nil
+}
@@ -0,0 +1,78 @@
+package pulumitest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+)
+
+// Transformed returns the resources of the registrations recorded by mocks,
+// like Registered, after running their transforms the way the engine does
+// before it registers a resource. The mocks only record the callbacks of the
+// transforms, so Transformed invokes them through the callback server of the
+// program, and the resources get the aliases the transforms return.
+func Transformed(ctx context.Context, registrations []pulumi.MockResourceArgs) ([]Resource, error) {
+	resources := make([]Resource, 0, len(registrations))
+	for _, r := range registrations {
+		if r.RegisterRPC == nil {
+			continue
+		}
+		transformed := Resource{
+			Name:    r.Name,
+			Type:    r.TypeToken,
+			Parent:  resource.URN(r.RegisterRPC.GetParent()),
+			Aliases: r.RegisterRPC.GetAliases(),
+		}
+		for _, callback := range r.RegisterRPC.GetTransforms() {
+			response, err := transform(ctx, callback, &pulumirpc.TransformRequest{
+				Type:       r.TypeToken,
+				Name:       r.Name,
+				Custom:     r.Custom,
+				Parent:     string(transformed.Parent),
+				Properties: r.RegisterRPC.GetObject(),
+				Options:    &pulumirpc.TransformResourceOptions{Aliases: transformed.Aliases},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("transforming %s: %w", r.Name, err)
+			}
+			// A transform that leaves the resource unchanged returns no options.
+			if response.GetOptions() != nil {
+				transformed.Aliases = response.GetOptions().GetAliases()
+			}
+		}
+		resources = append(resources, transformed)
+	}
+	return resources, nil
+}
+
+// transform invokes the transform of the callback.
+func transform(ctx context.Context, callback *pulumirpc.Callback, request *pulumirpc.TransformRequest) (*pulumirpc.TransformResponse, error) {
+	conn, err := grpc.NewClient(callback.GetTarget(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	marshaled, err := proto.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	invoked, err := pulumirpc.NewCallbacksClient(conn).Invoke(ctx, &pulumirpc.CallbackInvokeRequest{
+		Token:   callback.GetToken(),
+		Request: marshaled,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var response pulumirpc.TransformResponse
+	if err := proto.Unmarshal(invoked.GetResponse(), &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}